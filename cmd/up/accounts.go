@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+var accountCommands = map[string]command{
+	"list": {usage: "accounts list [--type saver|transactional|home-loan] [--ownership individual|joint]", run: accountsList},
+	"get":  {usage: "accounts get <account-id>", run: accountsGet},
+}
+
+func accountsList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("accounts list", flag.ContinueOnError)
+	accountType := fs.String("type", "", "only list accounts of this type")
+	ownership := fs.String("ownership", "", "only list accounts with this ownership")
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	opts := &up.ListAccountsOptions{
+		AccountType:   up.AccountTypeEnum(enumValue(*accountType)),
+		OwnershipType: up.OwnershipTypeEnum(enumValue(*ownership)),
+	}
+	resp, _, err := a.client.Accounts.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, accountView, resp.Data)
+}
+
+func accountsGet(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("accounts get", flag.ContinueOnError)
+	out := a.outputFlags(fs)
+	out.single = true
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	account, _, err := a.client.Accounts.Get(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, accountView, []up.Account{*account})
+}
+
+var accountView = view[up.Account]{
+	columns: []column[up.Account]{
+		{name: "id", value: func(acc up.Account) string { return acc.ID }},
+		{name: "name", value: func(acc up.Account) string { return acc.Attributes.DisplayName }},
+		{name: "type", value: func(acc up.Account) string { return string(acc.Attributes.AccountType) }},
+		{name: "ownership", value: func(acc up.Account) string { return string(acc.Attributes.OwnershipType) }},
+		moneyColumn("balance", func(acc up.Account) *up.MoneyObject { return &acc.Attributes.Balance }),
+		{name: "currency", value: func(acc up.Account) string { return acc.Attributes.Balance.CurrencyCode }},
+		timeColumn("created", func(acc up.Account) *time.Time {
+			t, err := time.Parse(time.RFC3339, acc.Attributes.CreatedAt)
+			if err != nil {
+				return nil
+			}
+			return &t
+		}),
+	},
+	defaults: []string{"id", "name", "type", "ownership", "balance"},
+}
+
+// enumValue converts a flag value such as "home-loan" to the API's HOME_LOAN form
+func enumValue(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(s, "-", "_"))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+var categoryCommands = map[string]command{
+	"list":  {usage: "categories list [--parent category-id]", run: categoriesList},
+	"get":   {usage: "categories get <category-id>", run: categoriesGet},
+	"set":   {usage: "categories set <transaction-id> <category-id>", run: categoriesSet},
+	"clear": {usage: "categories clear <transaction-id>", run: categoriesClear},
+}
+
+func categoriesList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("categories list", flag.ContinueOnError)
+	parent := fs.String("parent", "", "only list the children of this category")
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	resp, _, err := a.client.Categories.List(ctx, &up.ListCategoriesOptions{Parent: *parent})
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, categoryView, resp.Data)
+}
+
+func categoriesGet(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("categories get", flag.ContinueOnError)
+	out := a.outputFlags(fs)
+	out.single = true
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	category, _, err := a.client.Categories.Get(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, categoryView, []up.Category{*category})
+}
+
+func categoriesSet(ctx context.Context, a *app, args []string) error {
+	ids, err := parseFlags(flag.NewFlagSet("categories set", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	if _, err := a.client.Categories.UpdateTransactionCategory(ctx, ids[0], ids[1]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "categorised %s as %s\n", ids[0], ids[1])
+	return nil
+}
+
+func categoriesClear(ctx context.Context, a *app, args []string) error {
+	ids, err := parseFlags(flag.NewFlagSet("categories clear", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err := a.client.Categories.RemoveTransactionCategory(ctx, ids[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "removed category from %s\n", ids[0])
+	return nil
+}
+
+var categoryView = view[up.Category]{
+	columns: []column[up.Category]{
+		{name: "id", value: func(c up.Category) string { return c.ID }},
+		{name: "name", value: func(c up.Category) string { return c.Attributes.Name }},
+		{name: "parent", value: func(c up.Category) string {
+			if c.Relationships.Parent.Data == nil {
+				return ""
+			}
+			return c.Relationships.Parent.Data.ID
+		}},
+		{name: "children", value: func(c up.Category) string {
+			ids := make([]string, len(c.Relationships.Children.Data))
+			for i, child := range c.Relationships.Children.Data {
+				ids[i] = child.ID
+			}
+			return strings.Join(ids, ",")
+		}},
+	},
+	defaults: []string{"id", "name", "parent"},
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// config is the CLI configuration file, stored as JSON:
+//
+//	{
+//	  "default": "alex",
+//	  "profiles": {
+//	    "alex": {"token": {"source": "command", "command": ["pass", "show", "up/alex"]}, "account": "..."},
+//	    "sam": {"token": {"source": "encrypted-file", "path": "~/.config/up/sam.token"}, "output": "json"}
+//	  }
+//	}
+type config struct {
+	// Token is used when no profiles are configured, as in the original
+	// single-user config file, and by profiles without a token source
+	Token    string              `json:"token,omitempty"`
+	Default  string              `json:"default,omitempty"`
+	Profiles map[string]*profile `json:"profiles,omitempty"`
+}
+
+// profile is a named set of credentials and defaults
+type profile struct {
+	Token tokenSpec `json:"token"`
+	// Account is the account transactions are listed from by default
+	Account string `json:"account,omitempty"`
+	// Output is the default --output format
+	Output string `json:"output,omitempty"`
+}
+
+// tokenSpec describes an up.TokenSource
+type tokenSpec struct {
+	// Source is one of env, file, command or encrypted-file. When empty the
+	// top level token is used.
+	Source  string   `json:"source"`
+	Env     string   `json:"env,omitempty"`
+	Path    string   `json:"path,omitempty"`
+	Command []string `json:"command,omitempty"`
+	// Passphrase unlocks an encrypted-file token. It defaults to $UP_TOKEN_PASSPHRASE.
+	Passphrase *tokenSpec `json:"passphrase,omitempty"`
+}
+
+// defaultConfigPath returns $XDG_CONFIG_HOME/up/config.json or the platform equivalent
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "up.json"
+	}
+	return filepath.Join(dir, "up", "config.json")
+}
+
+// loadConfig reads the config file at path. A missing file is an empty config.
+func loadConfig(path string) (*config, error) {
+	cfg := &config{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("decoding config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// profile returns the profile to use. An explicitly named profile wins,
+// then $UP_TOKEN, then the default profile, the only profile, and finally
+// the top level token.
+func (cfg *config) profile(name string) (string, *profile, error) {
+	if name != "" {
+		p, ok := cfg.Profiles[name]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown profile %q, want one of %s", name, strings.Join(cfg.profileNames(), ", "))
+		}
+		return name, p, nil
+	}
+
+	if os.Getenv("UP_TOKEN") != "" {
+		return "", &profile{Token: tokenSpec{Source: "env", Env: "UP_TOKEN"}}, nil
+	}
+	if cfg.Default != "" {
+		return cfg.profile(cfg.Default)
+	}
+	if names := cfg.profileNames(); len(names) == 1 {
+		return names[0], cfg.Profiles[names[0]], nil
+	}
+	if len(cfg.Profiles) > 1 {
+		return "", nil, fmt.Errorf("several profiles are configured: choose one with --profile or set \"default\"")
+	}
+	if cfg.Token != "" {
+		return "", &profile{}, nil
+	}
+	return "", nil, errors.New("no API token: set UP_TOKEN or configure a profile")
+}
+
+// databaseName returns the name of the sync database for the profile
+// chosen by name, or by default. Unlike profile it ignores $UP_TOKEN, so
+// commands that need a token and local commands that do not pick the same
+// database.
+func (cfg *config) databaseName(name string) string {
+	if name != "" {
+		return name
+	}
+	if cfg.Default != "" {
+		return cfg.Default
+	}
+	if names := cfg.profileNames(); len(names) == 1 {
+		return names[0]
+	}
+	return "default"
+}
+
+func (cfg *config) profileNames() []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// tokenSource builds the token source described by spec
+func (cfg *config) tokenSource(spec tokenSpec) (up.TokenSource, error) {
+	switch spec.Source {
+	case "":
+		if cfg.Token == "" {
+			return nil, errors.New("no token: set a token source or a top level \"token\"")
+		}
+		return up.StaticToken(cfg.Token), nil
+	case "env":
+		if spec.Env == "" {
+			return nil, errors.New("env token source needs \"env\"")
+		}
+		return up.EnvToken(spec.Env), nil
+	case "file":
+		if spec.Path == "" {
+			return nil, errors.New("file token source needs \"path\"")
+		}
+		return up.FileToken(expandHome(spec.Path)), nil
+	case "command":
+		if len(spec.Command) == 0 {
+			return nil, errors.New("command token source needs \"command\"")
+		}
+		return up.CommandToken(spec.Command[0], spec.Command[1:]...), nil
+	case "encrypted-file":
+		if spec.Path == "" {
+			return nil, errors.New("encrypted-file token source needs \"path\"")
+		}
+		passphrase := up.EnvToken("UP_TOKEN_PASSPHRASE")
+		if spec.Passphrase != nil {
+			var err error
+			if passphrase, err = cfg.tokenSource(*spec.Passphrase); err != nil {
+				return nil, fmt.Errorf("passphrase: %w", err)
+			}
+		}
+		return up.EncryptedFileToken(expandHome(spec.Path), passphrase), nil
+	default:
+		return nil, fmt.Errorf("unknown token source %q, want env, file, command or encrypted-file", spec.Source)
+	}
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && rest[0] != '/' && rest[0] != filepath.Separator) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTokenSource(t *testing.T) {
+	cfg := &config{Token: "up:yeah:top"}
+	tests := []struct {
+		spec tokenSpec
+		want string
+		// err is part of the expected error, empty for success
+		err string
+	}{
+		{tokenSpec{}, "up:yeah:top", ""},
+		{tokenSpec{Source: "env", Env: "UP_TEST_TOKEN"}, "up:yeah:env", ""},
+		{tokenSpec{Source: "command", Command: []string{"echo", "up:yeah:cmd"}}, "up:yeah:cmd", ""},
+		{tokenSpec{Source: "env"}, "", "needs \"env\""},
+		{tokenSpec{Source: "file"}, "", "needs \"path\""},
+		{tokenSpec{Source: "command"}, "", "needs \"command\""},
+		{tokenSpec{Source: "encrypted-file"}, "", "needs \"path\""},
+		{tokenSpec{Source: "keychain"}, "", "unknown token source"},
+	}
+	t.Setenv("UP_TEST_TOKEN", "up:yeah:env")
+	for _, tt := range tests {
+		src, err := cfg.tokenSource(tt.spec)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%+v: returned %v, want %q", tt.spec, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", tt.spec, err)
+			continue
+		}
+		if token, err := src.Token(context.Background()); err != nil || token != tt.want {
+			t.Errorf("%+v: Token = %q, %v, want %q", tt.spec, token, err, tt.want)
+		}
+	}
+}
+
+func TestProfileWithoutToken(t *testing.T) {
+	t.Setenv("UP_TOKEN", "")
+	t.Setenv("UP_PROFILE", "")
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"profiles":{"alex":{"account":"acc-1"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The missing token is reported before any request is made
+	var stdout, stderr strings.Builder
+	err := run(context.Background(), []string{"--config", path, "ping"}, strings.NewReader(""), &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `profile "alex"`) || !strings.Contains(err.Error(), "no token") {
+		t.Errorf("run returned %v, want a config error naming the profile", err)
+	}
+}
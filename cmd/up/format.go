@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+	"gopkg.in/yaml.v3"
+)
+
+// formats are the values accepted by --output
+var formats = []string{"table", "json", "jsonl", "csv", "yaml"}
+
+// outputOptions holds the output flags of a command
+type outputOptions struct {
+	format   string
+	columns  string
+	template string
+	// single encodes one result as an object rather than a list, for get commands
+	single bool
+}
+
+// outputFlags registers --output, --columns and --template on fs
+func (a *app) outputFlags(fs *flag.FlagSet) *outputOptions {
+	o := &outputOptions{}
+	fs.StringVar(&o.format, "output", a.format, "output format: "+strings.Join(formats, ", "))
+	fs.StringVar(&o.columns, "columns", "", "comma separated columns to show, or \"all\"")
+	fs.StringVar(&o.template, "template", "", "Go template executed for each result, e.g. '{{.ID}} {{money .Attributes.Amount}}'")
+	return o
+}
+
+// column is a named field of a resource. value is used by CSV and the
+// structured formats; text, when set, is the friendlier form shown in tables.
+type column[T any] struct {
+	name  string
+	value func(T) string
+	text  func(T) string
+}
+
+// moneyColumn shows an amount as "-$4.50" in tables and "-4.50" elsewhere
+func moneyColumn[T any](name string, amount func(T) *up.MoneyObject) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item T) string {
+			if m := amount(item); m != nil {
+				return m.Value
+			}
+			return ""
+		},
+		text: func(item T) string {
+			if m := amount(item); m != nil {
+				return money(*m)
+			}
+			return ""
+		},
+	}
+}
+
+// timeColumn shows a time in local time in tables and as RFC 3339 elsewhere
+func timeColumn[T any](name string, at func(T) *time.Time) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item T) string {
+			if t := at(item); t != nil {
+				return t.Format(time.RFC3339)
+			}
+			return ""
+		},
+		text: func(item T) string {
+			if t := at(item); t != nil {
+				return formatTime(*t)
+			}
+			return ""
+		},
+	}
+}
+
+// view describes how a resource type is shown as rows
+type view[T any] struct {
+	columns  []column[T]
+	defaults []string
+}
+
+// selected returns the columns named by spec, the defaults when spec is
+// empty or every column for "all"
+func (v view[T]) selected(spec string) ([]column[T], error) {
+	names := v.defaults
+	switch spec {
+	case "":
+	case "all":
+		return v.columns, nil
+	default:
+		names = strings.Split(spec, ",")
+	}
+
+	cols := make([]column[T], 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		i := slices.IndexFunc(v.columns, func(c column[T]) bool { return c.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unknown column %q, want one of %s", errUsage, name, strings.Join(v.names(), ", "))
+		}
+		cols = append(cols, v.columns[i])
+	}
+	return cols, nil
+}
+
+func (v view[T]) names() []string {
+	names := make([]string, len(v.columns))
+	for i, c := range v.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// render writes items in the format selected by o. JSON, JSON Lines and YAML
+// output the API resources unchanged unless --columns is given, in which
+// case each item becomes an object of the selected columns.
+func render[T any](w io.Writer, o *outputOptions, v view[T], items []T) error {
+	if o.template != "" {
+		return renderTemplate(w, o.template, items)
+	}
+
+	cols, err := v.selected(o.columns)
+	if err != nil {
+		return err
+	}
+	values := func(item T) []string {
+		row := make([]string, len(cols))
+		for i, c := range cols {
+			if c.text != nil && o.format == "table" {
+				row[i] = c.text(item)
+			} else {
+				row[i] = c.value(item)
+			}
+		}
+		return row
+	}
+	header := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.name
+	}
+
+	// records returns what the structured formats encode for each item
+	records := func() []any {
+		out := make([]any, len(items))
+		for i, item := range items {
+			if o.columns == "" {
+				out[i] = item
+			} else {
+				out[i] = orderedRow{keys: header, values: values(item)}
+			}
+		}
+		return out
+	}
+	document := func() any {
+		r := records()
+		if o.single && len(r) == 1 {
+			return r[0]
+		}
+		return r
+	}
+
+	switch o.format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, item := range items {
+			fmt.Fprintln(tw, strings.Join(values(item), "\t"))
+		}
+		return tw.Flush()
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		for _, item := range items {
+			cw.Write(values(item))
+		}
+		cw.Flush()
+		return cw.Error()
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(document())
+	case "jsonl":
+		enc := json.NewEncoder(w)
+		for _, r := range records() {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "yaml":
+		return encodeYAML(w, document())
+	default:
+		return fmt.Errorf("%w: unknown output format %q, want one of %s", errUsage, o.format, strings.Join(formats, ", "))
+	}
+}
+
+// encodeYAML writes v as YAML using its JSON field names. Going through JSON
+// keeps the API's camelCase keys and field order, and MoneyObject and time
+// values look the same as in JSON output.
+func encodeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle clears the flow and quoting styles YAML decoding records for
+// JSON input, so the encoder picks its usual block style
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
+
+// templateFuncs are available to --template in addition to the builtins
+var templateFuncs = template.FuncMap{
+	"money": money,
+	"time":  formatTime,
+	"deref": deref,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": strings.Join,
+}
+
+// renderTemplate executes text once per item, each on its own line
+func renderTemplate[T any](w io.Writer, text string, items []T) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	var buf bytes.Buffer
+	for _, item := range items {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderedRow encodes as a JSON object with keys in column order
+type orderedRow struct {
+	keys   []string
+	values []string
+}
+
+func (r orderedRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		value, _ := json.Marshal(r.values[i])
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// money formats an API amount, falling back to the raw value if it does not parse
+func money(m up.MoneyObject) string {
+	v, err := m.Money()
+	if err != nil {
+		return m.Value + " " + m.CurrencyCode
+	}
+	return v.String()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// relationshipID returns the ID of an optional category relationship
+func relationshipID(r *up.CategoryRelationship) string {
+	if r == nil || r.Data == nil {
+		return ""
+	}
+	return r.Data.ID
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jordanst3wart/up-client/up"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTransactionsListFormats(t *testing.T) {
+	_, cli := newCLI(t)
+	newestFirst := []string{"tx-5", "tx-4", "tx-3", "tx-2", "tx-1"}
+
+	t.Run("table", func(t *testing.T) {
+		out, err := cli("transactions", "list")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := lines(out)
+		if len(rows) != 6 || !strings.HasPrefix(rows[0], "ID ") || !strings.Contains(rows[0], "DESCRIPTION") {
+			t.Fatalf("table = %q", out)
+		}
+		if !strings.HasPrefix(rows[1], "tx-5 ") || !strings.Contains(rows[1], "Uber Eats") || !strings.Contains(rows[1], "-$32.10") {
+			t.Errorf("first row = %q", rows[1])
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		out, err := cli("transactions", "list", "--output", "csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"id", "created", "status", "amount", "description", "category", "tags"}; !slices.Equal(records[0], want) {
+			t.Errorf("header = %v, want %v", records[0], want)
+		}
+		if len(records) != 6 || !slices.Equal(records[4], []string{"tx-2", "2024-03-02T09:00:00+11:00", "SETTLED", "-87.20", "Woolworths", "groceries", ""}) {
+			t.Errorf("records = %v", records)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := cli("transactions", "list", "--output", "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var txs []up.Transaction
+		if err := json.Unmarshal([]byte(out), &txs); err != nil {
+			t.Fatalf("decoding %q: %v", out, err)
+		}
+		if got := transactionIDs(txs); !slices.Equal(got, newestFirst) {
+			t.Errorf("ids = %v, want %v", got, newestFirst)
+		}
+		if txs[1].Attributes.Amount.Value != "-65.20" || !slices.Equal(txs[1].Relationships.Tags.TagIDs(), []string{"Holiday"}) {
+			t.Errorf("tx-4 = %+v", txs[1])
+		}
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		out, err := cli("transactions", "list", "--output", "jsonl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, line := range lines(out) {
+			var tx up.Transaction
+			if err := json.Unmarshal([]byte(line), &tx); err != nil {
+				t.Fatalf("decoding line %q: %v", line, err)
+			}
+			ids = append(ids, tx.ID)
+		}
+		if !slices.Equal(ids, newestFirst) {
+			t.Errorf("ids = %v, want %v", ids, newestFirst)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		out, err := cli("transactions", "list", "--output", "yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var docs []map[string]any
+		if err := yaml.Unmarshal([]byte(out), &docs); err != nil {
+			t.Fatalf("decoding %q: %v", out, err)
+		}
+		attrs, _ := docs[0]["attributes"].(map[string]any)
+		if len(docs) != 5 || docs[0]["id"] != "tx-5" || attrs["description"] != "Uber Eats" {
+			t.Errorf("first document = %v", docs[0])
+		}
+		// Keys are the API's, not Go field names
+		if !strings.Contains(out, "isCategorizable: true") {
+			t.Errorf("yaml does not use the API's field names:\n%s", out)
+		}
+	})
+
+	t.Run("template", func(t *testing.T) {
+		out, err := cli("transactions", "list", "--template", "{{.ID}} {{money .Attributes.Amount}} {{join .Relationships.Tags.TagIDs \"+\"}}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := lines(out)
+		if len(rows) != 5 || rows[1] != "tx-4 -$65.20 Holiday" || rows[4] != "tx-1 -$4.50 " {
+			t.Errorf("template output = %q", rows)
+		}
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		out, err := cli("transactions", "list", "--output", "json", "--columns", "id,amount,tags")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rows []map[string]string
+		if err := json.Unmarshal([]byte(out), &rows); err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 5 || len(rows[0]) != 3 || rows[2]["id"] != "tx-3" || rows[2]["amount"] != "3200.00" || rows[2]["tags"] != "Work" {
+			t.Errorf("rows = %v", rows)
+		}
+		// Columns keep the order they were asked for
+		if !strings.HasPrefix(strings.TrimSpace(out), "[\n  {\n    \"id\": \"tx-5\",\n    \"amount\"") {
+			t.Errorf("columns out of order:\n%s", out)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+// Command up is a command-line client for the Up banking API.
+//
+//	up accounts list
+//	up transactions list --since 2024-01-01 --status settled --category groceries
+//	up categories set <transaction-id> <category-id>
+//	up webhooks create --url https://example.com/hook --description ledger
+//
+// The API token comes from the profile chosen with --profile or $UP_PROFILE,
+// else $UP_TOKEN, else the default profile in the config file. $UP_BASE_URL
+// points the client at another server, such as a uptest fake.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// errUsage marks errors caused by invalid arguments
+var errUsage = errors.New("usage error")
+
+// app holds what every command needs
+type app struct {
+	client *up.Client
+	in     io.Reader
+	out    io.Writer
+	cfg    *config
+	// configPath is the config file in use, which sync databases sit beside
+	configPath string
+	// dbName names the default sync database, see config.databaseName
+	dbName string
+	// profile is the name of the selected profile, empty if none is configured
+	profile string
+	// format is the default for --output
+	format string
+	// account is the default account for transaction listings
+	account string
+}
+
+// command is a leaf subcommand such as "accounts list"
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+	// local commands do not call the API and run without a token
+	local bool
+}
+
+// groups maps each resource to its subcommands
+var groups = map[string]map[string]command{
+	"accounts":     accountCommands,
+	"transactions": transactionCommands,
+	"categories":   categoryCommands,
+	"tags":         tagCommands,
+	"webhooks":     webhookCommands,
+	"profiles":     profileCommands,
+	"token":        tokenCommands,
+	"sync":         syncCommands,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "up:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	global := flag.NewFlagSet("up", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	configPath := global.String("config", defaultConfigPath(), "path to the config file")
+	profileName := global.String("profile", os.Getenv("UP_PROFILE"), "profile to use from the config file, defaults to $UP_PROFILE")
+	global.Usage = func() { printUsage(stderr) }
+	if err := global.Parse(args); err != nil {
+		return err
+	}
+	args = global.Args()
+
+	if len(args) == 0 {
+		printUsage(stderr)
+		return errUsage
+	}
+
+	var cmd command
+	switch group, ok := groups[args[0]]; {
+	case args[0] == "ping":
+		cmd, args = pingCommand, args[1:]
+	case ok && len(args) > 1 && group[args[1]].run != nil:
+		cmd, args = group[args[1]], args[2:]
+	default:
+		printUsage(stderr)
+		return errUsage
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	a := &app{
+		in:         stdin,
+		out:        stdout,
+		cfg:        cfg,
+		configPath: *configPath,
+		dbName:     cfg.databaseName(*profileName),
+		format:     "table",
+	}
+
+	// Local commands use the profile's defaults when there is one but need no token
+	name, p, err := cfg.profile(*profileName)
+	if err != nil && (!cmd.local || *profileName != "") {
+		return err
+	}
+	if err == nil {
+		a.profile, a.account = name, p.Account
+		if p.Output != "" {
+			a.format = p.Output
+		}
+	}
+
+	if !cmd.local {
+		src, err := cfg.tokenSource(p.Token)
+		if err != nil {
+			if name == "" {
+				return fmt.Errorf("config %s: %w", *configPath, err)
+			}
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+
+		opts := []up.Option{
+			up.WithUserAgent("up-cli/1.0"),
+			up.WithTokenSource(up.CachedToken(src)),
+		}
+		if baseURL := os.Getenv("UP_BASE_URL"); baseURL != "" {
+			opts = append(opts, up.WithBaseURL(baseURL))
+		}
+		if a.client, err = up.New("", opts...); err != nil {
+			return err
+		}
+	}
+
+	err = cmd.run(ctx, a, args)
+	if errors.Is(err, errUsage) {
+		if err != errUsage {
+			fmt.Fprintln(stderr, "up:", err)
+		}
+		fmt.Fprintf(stderr, "usage: up %s\n", cmd.usage)
+	}
+	return err
+}
+
+var pingCommand = command{
+	usage: "ping",
+	run: func(ctx context.Context, a *app, args []string) error {
+		if _, err := parseFlags(flag.NewFlagSet("ping", flag.ContinueOnError), args, 0); err != nil {
+			return err
+		}
+		resp, _, err := a.client.Utility.Ping(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.out, "%s %s\n", resp.Meta.StatusEmoji, resp.Meta.ID)
+		return nil
+	},
+}
+
+func printUsage(w io.Writer) {
+	var lines []string
+	for _, group := range groups {
+		for _, cmd := range group {
+			lines = append(lines, "  up "+cmd.usage)
+		}
+	}
+	lines = append(lines, "  up "+pingCommand.usage)
+	slices.Sort(lines)
+
+	fmt.Fprintln(w, "usage: up [--config path] [--profile name] <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	fmt.Fprintln(w, strings.Join(lines, "\n"))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands that show results accept --output table|json|jsonl|csv|yaml, --columns and --template")
+}
+
+// parseFlags parses fs from args, allowing flags and positional arguments to
+// be interleaved, and checks exactly nargs positional arguments were given.
+// A negative nargs requires at least -nargs.
+func parseFlags(fs *flag.FlagSet, args []string, nargs int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if (nargs >= 0 && len(positional) != nargs) || (nargs < 0 && len(positional) < -nargs) {
+		return nil, errUsage
+	}
+	return positional, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jordanst3wart/up-client/up"
+	"github.com/jordanst3wart/up-client/uptest"
+)
+
+// newCLI starts a fake server seeded with the default fixtures and returns
+// a function running the CLI against it with UP_TOKEN set
+func newCLI(t *testing.T) (*uptest.Server, func(args ...string) (string, error)) {
+	t.Helper()
+	srv := uptest.NewServer(nil)
+	t.Cleanup(srv.Close)
+
+	t.Setenv("UP_BASE_URL", srv.URL+"/api/v1/")
+	t.Setenv("UP_TOKEN", uptest.Token)
+	t.Setenv("UP_PROFILE", "")
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	return srv, func(args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		err := run(context.Background(), append([]string{"--config", configPath}, args...), strings.NewReader(""), &stdout, &stderr)
+		return stdout.String(), err
+	}
+}
+
+// lines splits output into its non-empty lines
+func lines(out string) []string {
+	return strings.FieldsFunc(out, func(r rune) bool { return r == '\n' })
+}
+
+func transactionIDs(txs []up.Transaction) []string {
+	ids := make([]string, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	return ids
+}
+
+func TestTransactionsListFilters(t *testing.T) {
+	_, cli := newCLI(t)
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--since", "2024-03-03T00:00:00+11:00"}, []string{"tx-5", "tx-4", "tx-3"}},
+		{[]string{"--since", "2024-03-03T00:00:00+11:00", "--until", "2024-03-05T00:00:00+11:00"}, []string{"tx-4", "tx-3"}},
+		{[]string{"--status", "held"}, []string{"tx-5"}},
+		{[]string{"--status", "settled", "--limit", "2"}, []string{"tx-4", "tx-3"}},
+		{[]string{"--tag", "Work"}, []string{"tx-3"}},
+		{[]string{"--category", "good-life"}, []string{"tx-5", "tx-1"}},
+		{[]string{"--account", "acc-saver"}, nil},
+	}
+	for _, tt := range tests {
+		args := append([]string{"transactions", "list", "--template", "{{.ID}}"}, tt.args...)
+		out, err := cli(args...)
+		if err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+			continue
+		}
+		if got := lines(out); !slices.Equal(got, tt.want) {
+			t.Errorf("%v: listed %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionsGet(t *testing.T) {
+	_, cli := newCLI(t)
+
+	// A single result is an object, not a list
+	out, err := cli("transactions", "get", "tx-3", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx up.Transaction
+	if err := json.Unmarshal([]byte(out), &tx); err != nil || tx.ID != "tx-3" {
+		t.Errorf("get = %q, %v", out, err)
+	}
+
+	if _, err := cli("transactions", "get", "tx-404"); !errors.Is(err, up.ErrNotFound) {
+		t.Errorf("get of a missing transaction returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestTagsAddRemove(t *testing.T) {
+	srv, cli := newCLI(t)
+
+	if _, err := cli("tags", "add", "tx-1", "Holiday", "Coffee"); err != nil {
+		t.Fatal(err)
+	}
+	tx, _ := srv.Transaction("tx-1")
+	if got := tx.Relationships.Tags.TagIDs(); !slices.Contains(got, "Holiday") || !slices.Contains(got, "Coffee") {
+		t.Errorf("tags after adding = %v", got)
+	}
+
+	if _, err := cli("tags", "remove", "tx-1", "Holiday"); err != nil {
+		t.Fatal(err)
+	}
+	tx, _ = srv.Transaction("tx-1")
+	if got := tx.Relationships.Tags.TagIDs(); !slices.Equal(got, []string{"Coffee"}) {
+		t.Errorf("tags after removing = %v, want [Coffee]", got)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	_, cli := newCLI(t)
+	for _, args := range [][]string{
+		{},
+		{"transactions"},
+		{"transactions", "shred"},
+		{"transactions", "get"},
+		{"transactions", "list", "--output", "xml"},
+		{"transactions", "list", "--columns", "id,colour"},
+		{"transactions", "list", "--since", "last tuesday"},
+		{"transactions", "list", "--template", "{{.ID"},
+		{"transactions", "list", "--bogus"},
+	} {
+		if _, err := cli(args...); !errors.Is(err, errUsage) {
+			t.Errorf("%v: returned %v, want a usage error", args, err)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	_, cli := newCLI(t)
+	out, err := cli("ping")
+	if err != nil || strings.TrimSpace(out) == "" {
+		t.Errorf("ping = %q, %v", out, err)
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	for _, s := range []string{"2024-01-31", "2024-01-31T10:00:00+11:00", "7d", "36h"} {
+		if got, err := parseTimeFlag("since", s); err != nil || got == nil {
+			t.Errorf("parseTimeFlag(%q) = %v, %v", s, got, err)
+		}
+	}
+	if got, err := parseTimeFlag("since", ""); got != nil || err != nil {
+		t.Errorf("empty flag = %v, %v, want nil", got, err)
+	}
+	for _, s := range []string{"-7d", "-1h", "yesterday"} {
+		if _, err := parseTimeFlag("since", s); !errors.Is(err, errUsage) {
+			t.Errorf("parseTimeFlag(%q) returned %v, want a usage error", s, err)
+		}
+	}
+}
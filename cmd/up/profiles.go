@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+var profileCommands = map[string]command{
+	"list": {usage: "profiles list", run: profilesList, local: true},
+}
+
+var tokenCommands = map[string]command{
+	"encrypt": {usage: "token encrypt --out path [--force] < token", run: tokenEncrypt, local: true},
+}
+
+// namedProfile is a profile with its name, as listed by profiles list
+type namedProfile struct {
+	Name    string `json:"name"`
+	Default bool   `json:"default"`
+	*profile
+}
+
+func profilesList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("profiles list", flag.ContinueOnError)
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	var profiles []namedProfile
+	for _, name := range a.cfg.profileNames() {
+		profiles = append(profiles, namedProfile{Name: name, Default: name == a.cfg.Default, profile: a.cfg.Profiles[name]})
+	}
+	return render(a.out, out, profileView, profiles)
+}
+
+var profileView = view[namedProfile]{
+	columns: []column[namedProfile]{
+		{name: "name", value: func(p namedProfile) string { return p.Name }},
+		{name: "default", value: func(p namedProfile) string {
+			if p.Default {
+				return "*"
+			}
+			return ""
+		}},
+		{name: "token", value: func(p namedProfile) string { return describeTokenSpec(p.Token) }},
+		{name: "account", value: func(p namedProfile) string { return p.Account }},
+		{name: "output", value: func(p namedProfile) string { return p.Output }},
+	},
+	defaults: []string{"name", "default", "token", "account", "output"},
+}
+
+// describeTokenSpec summarises where a token comes from without revealing it
+func describeTokenSpec(spec tokenSpec) string {
+	switch spec.Source {
+	case "":
+		return "config"
+	case "env":
+		return "env $" + spec.Env
+	case "file", "encrypted-file":
+		return spec.Source + " " + spec.Path
+	case "command":
+		return "command " + strings.Join(spec.Command, " ")
+	default:
+		return spec.Source
+	}
+}
+
+func tokenEncrypt(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("token encrypt", flag.ContinueOnError)
+	path := fs.String("out", "", "encrypted token file to write (required)")
+	force := fs.Bool("force", false, "overwrite an existing file")
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if *path == "" {
+		return errUsage
+	}
+
+	passphrase := os.Getenv("UP_TOKEN_PASSPHRASE")
+	if passphrase == "" {
+		return errors.New("set UP_TOKEN_PASSPHRASE to the passphrase to encrypt with")
+	}
+	token, err := bufio.NewReader(a.in).ReadString('\n')
+	if token = strings.TrimSpace(token); token == "" {
+		if err != nil {
+			return fmt.Errorf("reading token from stdin: %w", err)
+		}
+		return errors.New("no token on stdin")
+	}
+
+	data, err := up.EncryptToken(token, passphrase)
+	if err != nil {
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if *force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(*path, flags, 0o600)
+	if err != nil {
+		return err
+	}
+	// An overwritten file keeps its old mode, so tighten it explicitly
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "wrote %s, use it in a profile with:\n", *path)
+	fmt.Fprintf(a.out, "  \"token\": {\"source\": \"encrypted-file\", \"path\": %q}\n", *path)
+	return nil
+}
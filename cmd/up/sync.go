@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jordanst3wart/up-client/upsync"
+)
+
+var syncCommands = map[string]command{
+	"run":     {usage: "sync run [--db path] [--full] [--overlap duration]", run: syncRun},
+	"status":  {usage: "sync status [--db path] [--limit n]", run: syncStatus, local: true},
+	"history": {usage: "sync history <transaction-id> [--db path]", run: syncHistory, local: true},
+}
+
+// dbFlag registers --db, which defaults to a database per profile next to
+// the config file
+func (a *app) dbFlag(fs *flag.FlagSet) *string {
+	path := filepath.Join(filepath.Dir(a.configPath), a.dbName+".db")
+	return fs.String("db", path, "SQLite database to sync into")
+}
+
+func syncRun(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("sync run", flag.ContinueOnError)
+	db := a.dbFlag(fs)
+	full := fs.Bool("full", false, "list every transaction instead of only recent ones")
+	overlap := fs.Duration("overlap", upsync.DefaultOverlap, "how far before the newest stored transaction to re-scan")
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	store, err := upsync.Open(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	syncer := upsync.NewSyncer(a.client.API(), store, &upsync.Options{Full: *full, Overlap: *overlap})
+	cp, err := syncer.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, checkpointView, []*upsync.Checkpoint{cp})
+}
+
+func syncStatus(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("sync status", flag.ContinueOnError)
+	db := a.dbFlag(fs)
+	limit := fs.Int("limit", 10, "number of sync runs to show")
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	store, err := openMirror(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	cps, err := store.Checkpoints(ctx, *limit)
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, checkpointView, cps)
+}
+
+func syncHistory(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("sync history", flag.ContinueOnError)
+	db := a.dbFlag(fs)
+	out := a.outputFlags(fs)
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	store, err := openMirror(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	changes, err := store.History(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, changeView, changes)
+}
+
+// openMirror opens an existing mirror, rather than creating an empty one
+func openMirror(path string) (*upsync.Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("no mirror at %s, run up sync run first", path)
+	}
+	return upsync.Open(path)
+}
+
+func count(n func(*upsync.Checkpoint) int) func(*upsync.Checkpoint) string {
+	return func(cp *upsync.Checkpoint) string { return strconv.Itoa(n(cp)) }
+}
+
+var checkpointView = view[*upsync.Checkpoint]{
+	columns: []column[*upsync.Checkpoint]{
+		{name: "id", value: func(cp *upsync.Checkpoint) string { return strconv.FormatInt(cp.ID, 10) }},
+		timeColumn("started", func(cp *upsync.Checkpoint) *time.Time { return &cp.StartedAt }),
+		{name: "duration", value: func(cp *upsync.Checkpoint) string {
+			return cp.FinishedAt.Sub(cp.StartedAt).Round(time.Millisecond).String()
+		}},
+		timeColumn("since", func(cp *upsync.Checkpoint) *time.Time {
+			if cp.Since.IsZero() {
+				return nil
+			}
+			return &cp.Since
+		}),
+		timeColumn("newest", func(cp *upsync.Checkpoint) *time.Time { return &cp.Newest }),
+		{name: "accounts", value: count(func(cp *upsync.Checkpoint) int { return cp.Accounts })},
+		{name: "categories", value: count(func(cp *upsync.Checkpoint) int { return cp.Categories })},
+		{name: "tags", value: count(func(cp *upsync.Checkpoint) int { return cp.Tags })},
+		{name: "fetched", value: count(func(cp *upsync.Checkpoint) int { return cp.Fetched })},
+		{name: "inserted", value: count(func(cp *upsync.Checkpoint) int { return cp.Inserted })},
+		{name: "updated", value: count(func(cp *upsync.Checkpoint) int { return cp.Updated })},
+		{name: "settled", value: count(func(cp *upsync.Checkpoint) int { return cp.Settled })},
+		{name: "deleted", value: count(func(cp *upsync.Checkpoint) int { return cp.Deleted })},
+		{name: "error", value: func(cp *upsync.Checkpoint) string { return cp.Err }},
+	},
+	defaults: []string{"id", "started", "since", "fetched", "inserted", "updated", "settled", "deleted", "error"},
+}
+
+var changeView = view[upsync.Change]{
+	columns: []column[upsync.Change]{
+		{name: "transaction", value: func(c upsync.Change) string { return c.TransactionID }},
+		timeColumn("changed", func(c upsync.Change) *time.Time { return &c.ChangedAt }),
+		{name: "field", value: func(c upsync.Change) string { return string(c.Field) }},
+		{name: "old", value: func(c upsync.Change) string { return c.Old }},
+		{name: "new", value: func(c upsync.Change) string { return c.New }},
+	},
+	defaults: []string{"changed", "field", "old", "new"},
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jordanst3wart/up-client/uptest"
+)
+
+func TestSyncDatabaseName(t *testing.T) {
+	cfg := &config{Profiles: map[string]*profile{"alex": {}, "sam": {}}}
+	tests := []struct {
+		cfg      *config
+		explicit string
+		want     string
+	}{
+		{cfg, "sam", "sam"},
+		{&config{Default: "alex", Profiles: cfg.Profiles}, "", "alex"},
+		{&config{Profiles: map[string]*profile{"alex": {}}}, "", "alex"},
+		{cfg, "", "default"},
+		{&config{Token: "up:yeah:top"}, "", "default"},
+	}
+	// $UP_TOKEN does not change the database
+	t.Setenv("UP_TOKEN", uptest.Token)
+	for _, tt := range tests {
+		if got := tt.cfg.databaseName(tt.explicit); got != tt.want {
+			t.Errorf("databaseName(%q) with %d profiles = %q, want %q", tt.explicit, len(tt.cfg.Profiles), got, tt.want)
+		}
+	}
+}
+
+func TestSyncRunAndStatusShareDatabase(t *testing.T) {
+	srv := uptest.NewServer(nil)
+	defer srv.Close()
+	t.Setenv("UP_BASE_URL", srv.URL+"/api/v1/")
+	t.Setenv("UP_PROFILE", "")
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	config := `{"default":"alex","profiles":{"alex":{"token":{"source":"env","env":"ALEX_TOKEN"}}}}`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cli := func(args ...string) (string, error) {
+		var stdout, stderr strings.Builder
+		err := run(context.Background(), append([]string{"--config", configPath}, args...), strings.NewReader(""), &stdout, &stderr)
+		return stdout.String(), err
+	}
+
+	// Syncing with $UP_TOKEN writes the default profile's database beside the config
+	t.Setenv("UP_TOKEN", uptest.Token)
+	if _, err := cli("sync", "run"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "alex.db")); err != nil {
+		t.Fatalf("database not beside the config file: %v", err)
+	}
+
+	// Local commands without $UP_TOKEN read the same database
+	t.Setenv("UP_TOKEN", "")
+	out, err := cli("sync", "status", "--output", "csv", "--columns", "fetched,inserted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lines(out); len(got) != 2 || got[1] != "5,5" {
+		t.Errorf("status = %q, want the run that inserted 5 transactions", out)
+	}
+
+	// Changes made through the CLI show in the history after the next sync
+	t.Setenv("UP_TOKEN", uptest.Token)
+	if _, err := cli("tags", "add", "tx-5", "Holiday"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli("sync", "run"); err != nil {
+		t.Fatal(err)
+	}
+	out, err = cli("sync", "history", "tx-5", "--output", "csv", "--columns", "field,old,new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lines(out); len(got) != 2 || got[1] != "tags,,Holiday" {
+		t.Errorf("history = %q, want the tag change", out)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+var tagCommands = map[string]command{
+	"list":   {usage: "tags list", run: tagsList},
+	"add":    {usage: "tags add <transaction-id> <tag>...", run: tagsAdd},
+	"remove": {usage: "tags remove <transaction-id> <tag>...", run: tagsRemove},
+}
+
+func tagsList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("tags list", flag.ContinueOnError)
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	resp, _, err := a.client.Tags.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, tagView, resp.Data)
+}
+
+func tagsAdd(ctx context.Context, a *app, args []string) error {
+	ids, err := parseFlags(flag.NewFlagSet("tags add", flag.ContinueOnError), args, -2)
+	if err != nil {
+		return err
+	}
+	if _, err := a.client.Tags.AddToTransaction(ctx, ids[0], ids[1:]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "tagged %s with %s\n", ids[0], strings.Join(ids[1:], ", "))
+	return nil
+}
+
+func tagsRemove(ctx context.Context, a *app, args []string) error {
+	ids, err := parseFlags(flag.NewFlagSet("tags remove", flag.ContinueOnError), args, -2)
+	if err != nil {
+		return err
+	}
+	if _, err := a.client.Tags.RemoveFromTransaction(ctx, ids[0], ids[1:]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "removed %s from %s\n", strings.Join(ids[1:], ", "), ids[0])
+	return nil
+}
+
+var tagView = view[up.Tag]{
+	columns: []column[up.Tag]{
+		{name: "id", value: func(t up.Tag) string { return t.ID }},
+	},
+	defaults: []string{"id"},
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+var transactionCommands = map[string]command{
+	"list": {
+		usage: "transactions list [--account id] [--since time] [--until time] [--status held|settled] [--category id] [--tag tag] [--limit n]",
+		run:   transactionsList,
+	},
+	"get": {usage: "transactions get <transaction-id>", run: transactionsGet},
+}
+
+func transactionsList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("transactions list", flag.ContinueOnError)
+	accountID := fs.String("account", a.account, "only list transactions for this account, or \"all\" to override the profile's account")
+	since := fs.String("since", "", "only list transactions created at or after this time, e.g. 2024-01-31, an RFC 3339 time or 7d ago")
+	until := fs.String("until", "", "only list transactions created before this time")
+	status := fs.String("status", "", "only list held or settled transactions")
+	category := fs.String("category", "", "only list transactions in this category")
+	tag := fs.String("tag", "", "only list transactions with this tag")
+	limit := fs.Int("limit", 100, "maximum number of transactions to list, 0 for all")
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	opts := &up.ListTransactionsOptions{
+		Status:   up.TransactionStatusEnum(enumValue(*status)),
+		Category: *category,
+		Tag:      *tag,
+	}
+	opts.MaxItems = *limit
+	var err error
+	if opts.Since, err = parseTimeFlag("since", *since); err != nil {
+		return err
+	}
+	if opts.Until, err = parseTimeFlag("until", *until); err != nil {
+		return err
+	}
+
+	var resp *up.TransactionListResponse
+	if *accountID != "" && *accountID != "all" {
+		resp, _, err = a.client.Transactions.ListByAccount(ctx, *accountID, opts)
+	} else {
+		resp, _, err = a.client.Transactions.List(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, transactionView, resp.Data)
+}
+
+func transactionsGet(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("transactions get", flag.ContinueOnError)
+	out := a.outputFlags(fs)
+	out.single = true
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	tx, _, err := a.client.Transactions.Get(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, transactionView, []up.Transaction{*tx})
+}
+
+var transactionView = view[up.Transaction]{
+	columns: []column[up.Transaction]{
+		{name: "id", value: func(tx up.Transaction) string { return tx.ID }},
+		timeColumn("created", func(tx up.Transaction) *time.Time { return &tx.Attributes.CreatedAt }),
+		timeColumn("settled", func(tx up.Transaction) *time.Time { return tx.Attributes.SettledAt }),
+		{name: "status", value: func(tx up.Transaction) string { return string(tx.Attributes.Status) }},
+		moneyColumn("amount", func(tx up.Transaction) *up.MoneyObject { return &tx.Attributes.Amount }),
+		{name: "currency", value: func(tx up.Transaction) string { return tx.Attributes.Amount.CurrencyCode }},
+		moneyColumn("foreign-amount", func(tx up.Transaction) *up.MoneyObject { return tx.Attributes.ForeignAmount }),
+		{name: "description", value: func(tx up.Transaction) string { return tx.Attributes.Description }},
+		{name: "message", value: func(tx up.Transaction) string { return deref(tx.Attributes.Message) }},
+		{name: "raw-text", value: func(tx up.Transaction) string { return deref(tx.Attributes.RawText) }},
+		{name: "account", value: func(tx up.Transaction) string { return tx.Relationships.Account.Data.ID }},
+		{name: "category", value: func(tx up.Transaction) string { return relationshipID(tx.Relationships.Category) }},
+		{name: "parent-category", value: func(tx up.Transaction) string { return relationshipID(tx.Relationships.ParentCategory) }},
+		{name: "tags", value: func(tx up.Transaction) string { return strings.Join(tx.Relationships.Tags.TagIDs(), ",") }},
+	},
+	defaults: []string{"id", "created", "status", "amount", "description", "category", "tags"},
+}
+
+// parseTimeFlag parses an RFC 3339 time, a local date such as 2024-01-31, or
+// an age such as 36h or 7d meaning that long ago. An empty value is nil.
+func parseTimeFlag(name, s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return &t, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			t := time.Now().AddDate(0, 0, -n)
+			return &t, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		t := time.Now().Add(-d)
+		return &t, nil
+	}
+	return nil, fmt.Errorf("%w: invalid --%s %q: want a date, an RFC 3339 time or an age such as 7d", errUsage, name, s)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+var webhookCommands = map[string]command{
+	"list":   {usage: "webhooks list", run: webhooksList},
+	"create": {usage: "webhooks create --url url [--description text]", run: webhooksCreate},
+	"delete": {usage: "webhooks delete <webhook-id>", run: webhooksDelete},
+	"ping":   {usage: "webhooks ping <webhook-id>", run: webhooksPing},
+	"logs":   {usage: "webhooks logs <webhook-id> [--limit n]", run: webhooksLogs},
+}
+
+func webhooksList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("webhooks list", flag.ContinueOnError)
+	out := a.outputFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	resp, _, err := a.client.Webhooks.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, webhookView, resp.Data)
+}
+
+func webhooksCreate(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("webhooks create", flag.ContinueOnError)
+	url := fs.String("url", "", "URL events are delivered to (required)")
+	description := fs.String("description", "", "description of the webhook")
+	out := a.outputFlags(fs)
+	out.single = true
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if *url == "" {
+		return errUsage
+	}
+
+	var desc *string
+	if *description != "" {
+		desc = description
+	}
+	webhook, _, err := a.client.Webhooks.Create(ctx, *url, desc)
+	if err != nil {
+		return err
+	}
+	if err := render(a.out, out, webhookView, []up.Webhook{*webhook}); err != nil {
+		return err
+	}
+	// The secret key is only returned when the webhook is created. The
+	// structured formats include it in the resource.
+	if out.format == "table" && out.template == "" {
+		fmt.Fprintf(a.out, "\nsecret key: %s\n", webhook.Attributes.SecretKey)
+	}
+	return nil
+}
+
+func webhooksDelete(ctx context.Context, a *app, args []string) error {
+	ids, err := parseFlags(flag.NewFlagSet("webhooks delete", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	if _, err := a.client.Webhooks.Delete(ctx, ids[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "deleted %s\n", ids[0])
+	return nil
+}
+
+func webhooksPing(ctx context.Context, a *app, args []string) error {
+	ids, err := parseFlags(flag.NewFlagSet("webhooks ping", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	event, _, err := a.client.Webhooks.Ping(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "sent %s event %s\n", event.Attributes.EventType, event.ID)
+	return nil
+}
+
+func webhooksLogs(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("webhooks logs", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "maximum number of deliveries to list, 0 for all")
+	out := a.outputFlags(fs)
+	ids, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, _, err := a.client.Webhooks.ListLogs(ctx, ids[0], &up.ListOptions{MaxItems: *limit})
+	if err != nil {
+		return err
+	}
+	return render(a.out, out, deliveryLogView, resp.Data)
+}
+
+var webhookView = view[up.Webhook]{
+	columns: []column[up.Webhook]{
+		{name: "id", value: func(w up.Webhook) string { return w.ID }},
+		{name: "url", value: func(w up.Webhook) string { return w.Attributes.URL }},
+		{name: "description", value: func(w up.Webhook) string { return deref(w.Attributes.Description) }},
+		timeColumn("created", func(w up.Webhook) *time.Time { return &w.Attributes.CreatedAt }),
+		{name: "secret-key", value: func(w up.Webhook) string { return w.Attributes.SecretKey }},
+	},
+	defaults: []string{"id", "url", "description", "created"},
+}
+
+var deliveryLogView = view[up.WebhookDeliveryLog]{
+	columns: []column[up.WebhookDeliveryLog]{
+		{name: "id", value: func(l up.WebhookDeliveryLog) string { return l.ID }},
+		timeColumn("created", func(l up.WebhookDeliveryLog) *time.Time { return &l.Attributes.CreatedAt }),
+		{name: "delivery", value: func(l up.WebhookDeliveryLog) string { return string(l.Attributes.DeliveryStatus) }},
+		{name: "status", value: func(l up.WebhookDeliveryLog) string {
+			if l.Attributes.Response == nil {
+				return ""
+			}
+			return strconv.Itoa(l.Attributes.Response.StatusCode)
+		}},
+		{name: "event", value: func(l up.WebhookDeliveryLog) string { return l.Relationships.WebhookEvent.Data.ID }},
+		{name: "request-body", value: func(l up.WebhookDeliveryLog) string { return l.Attributes.Request.Body }},
+		{name: "response-body", value: func(l up.WebhookDeliveryLog) string {
+			if l.Attributes.Response == nil {
+				return ""
+			}
+			return l.Attributes.Response.Body
+		}},
+	},
+	defaults: []string{"id", "created", "delivery", "status", "event"},
+}
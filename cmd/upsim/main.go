@@ -0,0 +1,110 @@
+// Command upsim posts simulated, signed Up webhook events to a local receiver.
+//
+//	upsim -url http://localhost:8080/webhook -secret s3cret -event created -tx tx-1 -amount -4.50
+//	upsim -url http://localhost:8080/webhook -secret s3cret -scenario held-then-settled -amount -1.00 -settled-amount -65.20
+//	upsim -url http://localhost:8080/webhook -secret s3cret -scenario my-scenario.json -serve :9090
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/jordanst3wart/up-client/up"
+	"github.com/jordanst3wart/up-client/upsim"
+)
+
+var eventTypes = map[string]up.WebhookEventTypeEnum{
+	"ping":    up.WebhookEventPing,
+	"created": up.WebhookEventTransactionCreated,
+	"settled": up.WebhookEventTransactionSettled,
+	"deleted": up.WebhookEventTransactionDeleted,
+}
+
+func main() {
+	target := flag.String("url", "", "receiver webhook URL (required)")
+	secret := flag.String("secret", os.Getenv("UP_WEBHOOK_SECRET"), "webhook secret key, defaults to $UP_WEBHOOK_SECRET")
+	event := flag.String("event", "ping", "event to send: ping, created, settled or deleted")
+	txID := flag.String("tx", "sim-transaction", "transaction ID for transaction events")
+	amount := flag.String("amount", "-10.00", "transaction amount for created and settled events")
+	settledAmount := flag.String("settled-amount", "", "amount the held-then-settled scenario settles for, defaults to -amount")
+	description := flag.String("description", "Simulated purchase", "transaction description")
+	scenario := flag.String("scenario", "", "scenario to run: held-then-settled, held-then-deleted or a JSON file")
+	serve := flag.String("serve", "", "address to serve GET /transactions/{id} on for hydration, e.g. :9090")
+	flag.Parse()
+
+	if *target == "" || *secret == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	sim := upsim.New(*target, *secret)
+
+	if *serve != "" {
+		srv := &http.Server{Addr: *serve, Handler: sim.Handler()}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatal(err)
+			}
+		}()
+		log.Printf("serving simulated transactions on %s", *serve)
+	}
+
+	if *settledAmount == "" {
+		*settledAmount = *amount
+	}
+
+	sc, err := loadScenario(*scenario, *event, *txID, *amount, *settledAmount, *description)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, err := sim.Run(ctx, sc)
+	for _, r := range results {
+		fmt.Printf("step %d: %s %s -> %d\n", r.Step, r.EventType, r.EventID, r.StatusCode)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *serve != "" {
+		log.Print("scenario complete, still serving transactions (ctrl-c to exit)")
+		<-ctx.Done()
+	}
+}
+
+// loadScenario resolves the -scenario flag, or builds a single-step scenario from the -event flags
+func loadScenario(name, event, txID, amount, settledAmount, description string) (*upsim.Scenario, error) {
+	switch name {
+	case "held-then-settled":
+		return upsim.HeldThenSettled(txID, description, amount, settledAmount), nil
+	case "held-then-deleted":
+		return upsim.HeldThenDeleted(txID, description, amount), nil
+	case "":
+	default:
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return upsim.LoadScenario(f)
+	}
+
+	eventType, ok := eventTypes[strings.ToLower(event)]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", event)
+	}
+	step := upsim.Step{Event: eventType}
+	if eventType != up.WebhookEventPing {
+		step.Transaction = &upsim.TransactionSpec{ID: txID, Description: description, Amount: amount}
+	}
+	return &upsim.Scenario{Steps: []upsim.Step{step}}, nil
+}
@@ -52,10 +52,7 @@ type AccountRelationships struct {
 }
 
 // AccountListResponse represents the response from listing accounts
-type AccountListResponse struct {
-	Data  []Account `json:"data"`
-	Links Links     `json:"links"`
-}
+type AccountListResponse = ListResponse[Account]
 
 // AccountGetResponse represents the response from getting a single account
 type AccountGetResponse struct {
@@ -71,26 +68,24 @@ type ListAccountsOptions struct {
 
 // List returns a list of all accounts
 func (s *AccountsService) List(ctx context.Context, opts *ListAccountsOptions) (*AccountListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "accounts.list")
 	u := "accounts"
+	var listOpts *ListOptions
 	if opts != nil {
 		var err error
 		u, err = addOptions(u, opts)
 		if err != nil {
 			return nil, nil, err
 		}
+		listOpts = &opts.ListOptions
 	}
 
-	var accounts AccountListResponse
-	resp, err := s.client.paginate(ctx, u, &accounts)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return &accounts, resp, nil
+	return paginate[Account](ctx, s.client, u, listOpts)
 }
 
 // Get returns a specific account by ID
 func (s *AccountsService) Get(ctx context.Context, accountID string) (*Account, *http.Response, error) {
+	ctx = withOperation(ctx, "accounts.get")
 	u := fmt.Sprintf("accounts/%s", accountID)
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
@@ -61,6 +61,7 @@ type ListCategoriesOptions struct {
 
 // List returns a list of all categories
 func (s *CategoriesService) List(ctx context.Context, opts *ListCategoriesOptions) (*CategoryListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "categories.list")
 	u := "categories"
 	if opts != nil {
 		var err error
@@ -86,6 +87,7 @@ func (s *CategoriesService) List(ctx context.Context, opts *ListCategoriesOption
 
 // Get returns a specific category by ID
 func (s *CategoriesService) Get(ctx context.Context, categoryID string) (*Category, *http.Response, error) {
+	ctx = withOperation(ctx, "categories.get")
 	u := fmt.Sprintf("categories/%s", categoryID)
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
@@ -108,6 +110,7 @@ type CategoryUpdateRequest struct {
 
 // UpdateTransactionCategory updates the category of a transaction
 func (s *CategoriesService) UpdateTransactionCategory(ctx context.Context, transactionID string, categoryID string) (*http.Response, error) {
+	ctx = withOperation(ctx, "categories.update_transaction_category")
 	u := fmt.Sprintf("transactions/%s/relationships/category", transactionID)
 
 	categoryData := &CategoryUpdateRequest{
@@ -127,6 +130,7 @@ func (s *CategoriesService) UpdateTransactionCategory(ctx context.Context, trans
 
 // RemoveTransactionCategory removes the category from a transaction
 func (s *CategoriesService) RemoveTransactionCategory(ctx context.Context, transactionID string) (*http.Response, error) {
+	ctx = withOperation(ctx, "categories.remove_transaction_category")
 	u := fmt.Sprintf("transactions/%s/relationships/category", transactionID)
 
 	categoryData := &CategoryUpdateRequest{
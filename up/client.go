@@ -9,21 +9,34 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
-	defaultBaseURL = "https://api.up.com.au/api/v1/"
-	userAgent      = "up-go-client/1.0"
+	defaultBaseURL   = "https://api.up.com.au/api/v1/"
+	defaultUserAgent = "up-go-client/1.0"
+	defaultTimeout   = 30 * time.Second
 )
 
 // Client manages communication with Up API
 type Client struct {
-	client  *http.Client
-	baseURL *url.URL
-	token   string
+	client      *http.Client
+	baseURL     *url.URL
+	userAgent   string
+	tokenSource TokenSource
+	// timeout and transport are set by options and applied to a copy of
+	// client once every option has run
+	timeout   *time.Duration
+	transport http.RoundTripper
+
+	retryPolicy *RetryPolicy
+	limiter     *rateLimiter
+	inFlight    chan struct{}
+	middlewares []Middleware
+	doer        Doer
 
 	common service // Reuse a single struct instead of creating one for each service
 
@@ -40,22 +53,45 @@ type service struct {
 	client *Client
 }
 
-// NewClient returns a new Up API client
+// NewClient returns a new Up API client. If httpClient is nil a client with
+// a 30 second timeout is used. See New for more configuration options.
 func NewClient(token string, httpClient *http.Client) *Client {
-	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: time.Second * 30,
-		}
-	}
+	// WithHTTPClient is the only option given and it cannot fail
+	c, _ := New(token, WithHTTPClient(httpClient))
+	return c
+}
 
+// New returns a new Up API client configured with the given options. token
+// may be empty if WithTokenSource is given.
+func New(token string, opts ...Option) (*Client, error) {
 	baseURL, _ := url.Parse(defaultBaseURL)
 
 	c := &Client{
-		client:  httpClient,
-		baseURL: baseURL,
-		token:   token,
+		client:      &http.Client{Timeout: defaultTimeout},
+		baseURL:     baseURL,
+		userAgent:   defaultUserAgent,
+		tokenSource: StaticToken(token),
+		retryPolicy: DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.timeout != nil || c.transport != nil {
+		hc := *c.client
+		if c.timeout != nil {
+			hc.Timeout = *c.timeout
+		}
+		if c.transport != nil {
+			hc.Transport = c.transport
+		}
+		c.client = &hc
 	}
 
+	c.doer = c.buildDoer()
 	c.common.client = c
 
 	// Initialize services
@@ -66,7 +102,13 @@ func NewClient(token string, httpClient *http.Client) *Client {
 	c.Webhooks = (*WebhooksService)(&c.common)
 	c.Utility = (*UtilityService)(&c.common)
 
-	return c
+	return c, nil
+}
+
+// BaseURL returns the URL that API paths are resolved against
+func (c *Client) BaseURL() *url.URL {
+	u := *c.baseURL
+	return &u
 }
 
 // newRequest creates an API request
@@ -96,33 +138,76 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", userAgent)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("User-Agent", c.userAgent)
 
 	return req, nil
 }
 
-// do sends an API request and returns the API response
+// do sends an API request and returns the API response. Each attempt waits for
+// the rate limiter and concurrency cap, if configured, and failed attempts are
+// retried according to the client's retry policy.
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	token, err := c.tokenSource.Token(ctx)
 	if err != nil {
-		if ctx.Err() != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		if err := c.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.doer.Do(req)
+		if c.limiter != nil {
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				c.limiter.slowDown()
+			} else if err == nil && resp.StatusCode < 400 {
+				c.limiter.speedUp()
+			}
+		}
+		if err != nil && ctx.Err() != nil {
+			c.release()
 			return nil, ctx.Err()
 		}
-		return nil, err
+
+		if !c.retryPolicy.shouldRetry(req, resp, err, attempt) {
+			defer c.release()
+			if err != nil {
+				return nil, err
+			}
+			return c.handleResponse(resp, v)
+		}
+
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		c.release()
+
+		if err := sleep(ctx, c.retryPolicy.backoff(resp, attempt)); err != nil {
+			return nil, err
+		}
 	}
+}
 
+// handleResponse decodes a response into v, or into an error if the API reported one
+func (c *Client) handleResponse(resp *http.Response, v interface{}) (*http.Response, error) {
 	defer resp.Body.Close()
 
-	// probably a bug here somewhere
 	if resp.StatusCode >= 400 {
-		errorResponse := &ErrorResponse{}
-		if err := json.NewDecoder(resp.Body).Decode(errorResponse); err != nil {
-			return resp, fmt.Errorf("http status %d: failed to decode error response", resp.StatusCode)
-		}
-		return resp, errorResponse
+		return resp, newAPIError(resp)
 	}
 
 	if v != nil {
@@ -131,7 +216,7 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*htt
 		}
 	}
 
-	return resp, err
+	return resp, nil
 }
 
 // addOptions adds the parameters in opt as URL query parameters to s.
@@ -160,6 +245,12 @@ type ListOptions struct {
 	PageSize int    `url:"page[size],omitempty"`
 	After    string `url:"page[after],omitempty"`
 	Before   string `url:"page[before],omitempty"`
+
+	// MaxItems and MaxPages limit how much List methods fetch. Zero means no
+	// limit. Set PageSize with MaxItems so Links.Next can resume after the
+	// last item returned.
+	MaxItems int `url:"-"`
+	MaxPages int `url:"-"`
 }
 
 // Links represents pagination links
@@ -180,6 +271,30 @@ func (e *ErrorResponse) Error() string {
 	return "unknown error"
 }
 
+// newAPIError builds an APIError from an error response, keeping the raw body
+// even when it is not a JSON error document
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return apiErr
+	}
+	apiErr.Body = body
+
+	errorResponse := &ErrorResponse{}
+	if err := json.Unmarshal(body, errorResponse); err == nil {
+		apiErr.Errors = errorResponse.Errors
+	}
+	return apiErr
+}
+
 // ErrorObject represents a single error from the Up API
 type ErrorObject struct {
 	Status string `json:"status"`
@@ -198,60 +313,100 @@ type MoneyObject struct {
 	ValueInBaseUnits int64  `json:"valueInBaseUnits"`
 }
 
-// paginate handles pagination for any API resource that returns a list response with a Next link
-func (c *Client) paginate(ctx context.Context, initialURL string, result interface{}) (*http.Response, error) {
-	nextPageURL := initialURL
+// ListResponse represents a paginated list response from the Up API
+type ListResponse[T any] struct {
+	Data  []T   `json:"data"`
+	Links Links `json:"links"`
 
-	// Get the value of the result pointer
-	resultValue := reflect.ValueOf(result).Elem()
+	// PagesFetched is the number of pages requested to build the response
+	PagesFetched int `json:"-"`
+}
 
-	// The first iteration is special since we need to initialize the result
-	firstPage := true
+// paginate follows Next links from initialURL and merges every page into a
+// single response, stopping early once the limits in opts are reached. When
+// it stops early Links.Next points at the first item not returned, so it can
+// be used to resume. To stay on item boundaries MaxItems shrinks the size of
+// the last page requested when PageSize is set. If a page still overshoots
+// MaxItems, because PageSize was left to the server's default, the surplus
+// is dropped and Links.Next is cleared rather than skip it. The returned
+// links carry the caller's PageSize, not the shrunk one.
+func paginate[T any](ctx context.Context, c *Client, initialURL string, opts *ListOptions) (*ListResponse[T], *http.Response, error) {
+	var maxItems, maxPages, pageSize int
+	if opts != nil {
+		maxItems, maxPages, pageSize = opts.MaxItems, opts.MaxPages, opts.PageSize
+	}
 
+	result := &ListResponse[T]{}
+	nextPageURL := initialURL
+
+	var (
+		resp   *http.Response
+		shrunk bool
+	)
 	for nextPageURL != "" {
+		if remaining := maxItems - len(result.Data); maxItems > 0 && remaining < pageSize {
+			u, err := withPageSize(nextPageURL, remaining)
+			if err != nil {
+				return nil, resp, err
+			}
+			nextPageURL, shrunk = u, true
+		}
+
 		req, err := c.newRequest("GET", nextPageURL, nil)
 		if err != nil {
-			return nil, err
+			return nil, resp, err
 		}
 
-		// Create a new instance of the same type as result
-		pageResult := reflect.New(resultValue.Type()).Interface()
-
-		resp, err := c.do(ctx, req, pageResult)
+		var page ListResponse[T]
+		resp, err = c.do(ctx, req, &page)
 		if err != nil {
-			return resp, err
+			return nil, resp, err
 		}
 
-		// Get the value of the page result
-		pageValue := reflect.ValueOf(pageResult).Elem()
-
-		if firstPage {
-			// First page: just copy the entire result
-			resultValue.Set(pageValue)
-			firstPage = false
-		} else {
-			// Subsequent pages: append Data field contents
-			resultData := resultValue.FieldByName("Data")
-			pageData := pageValue.FieldByName("Data")
-
-			if resultData.IsValid() && pageData.IsValid() {
-				resultData.Set(reflect.AppendSlice(resultData, pageData))
-			}
+		result.PagesFetched++
+		result.Data = append(result.Data, page.Data...)
+		if result.PagesFetched == 1 {
+			result.Links.Prev = page.Links.Prev
 		}
+		result.Links.Next = page.Links.Next
 
-		// Check for a Next link
-		linksField := pageValue.FieldByName("Links")
-		if !linksField.IsValid() {
+		if maxItems > 0 && len(result.Data) >= maxItems {
+			if len(result.Data) > maxItems {
+				result.Data = result.Data[:maxItems]
+				result.Links.Next = ""
+			}
 			break
 		}
-
-		nextField := linksField.FieldByName("Next")
-		if !nextField.IsValid() || nextField.String() == "" {
+		if maxPages > 0 && result.PagesFetched >= maxPages {
 			break
 		}
 
-		nextPageURL = nextField.String()
+		nextPageURL = page.Links.Next
 	}
 
-	return nil, nil
+	if shrunk {
+		for _, link := range []*string{&result.Links.Next, &result.Links.Prev} {
+			if *link == "" {
+				continue
+			}
+			u, err := withPageSize(*link, pageSize)
+			if err != nil {
+				return nil, resp, err
+			}
+			*link = u
+		}
+	}
+	return result, resp, nil
+}
+
+// withPageSize returns pageURL with its page[size] parameter set to size
+func withPageSize(pageURL string, size int) (string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("page[size]", strconv.Itoa(size))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
 }
@@ -0,0 +1,100 @@
+package up
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newTestClient returns a client that sends requests to handler
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := New("test-token", append([]Option{WithBaseURL(srv.URL)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+// pagedHandler serves n items built by item as pages of page[size] items,
+// two by default. page[after] is the offset of the first item of the page.
+func pagedHandler(n int, item func(i int) any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		size, start := 2, 0
+		if v := q.Get("page[size]"); v != "" {
+			size, _ = strconv.Atoi(v)
+		}
+		if v := q.Get("page[after]"); v != "" {
+			start, _ = strconv.Atoi(v)
+		}
+		end := min(start+size, n)
+
+		page := ListResponse[any]{Data: []any{}}
+		for i := start; i < end; i++ {
+			page.Data = append(page.Data, item(i))
+		}
+		if end < n {
+			q.Set("page[after]", strconv.Itoa(end))
+			page.Links.Next = "http://" + r.Host + r.URL.Path + "?" + q.Encode()
+		}
+		json.NewEncoder(w).Encode(&page)
+	})
+}
+
+// tagItem builds the tag served at position i
+func tagItem(i int) any {
+	return map[string]any{"type": "tags", "id": "tag-" + strconv.Itoa(i)}
+}
+
+func TestPaginateMaxItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      ListOptions
+		wantItems int
+		wantPages int
+		// wantNext is the page[after] offset of Links.Next, -1 if there is none
+		wantNext int
+	}{
+		{"all pages", ListOptions{PageSize: 2}, 7, 4, -1},
+		{"max items on a page boundary", ListOptions{PageSize: 2, MaxItems: 4}, 4, 2, 4},
+		{"max items inside a page", ListOptions{PageSize: 2, MaxItems: 3}, 3, 2, 3},
+		{"max items below page size", ListOptions{PageSize: 5, MaxItems: 1}, 1, 1, 1},
+		{"max items without page size", ListOptions{MaxItems: 3}, 3, 2, -1},
+		{"max pages", ListOptions{PageSize: 3, MaxPages: 2}, 6, 2, 6},
+	}
+	for _, tt := range tests {
+		c := newTestClient(t, pagedHandler(7, tagItem))
+		resp, _, err := c.Tags.List(t.Context(), &tt.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(resp.Data) != tt.wantItems || resp.PagesFetched != tt.wantPages {
+			t.Errorf("%s: got %d items in %d pages, want %d in %d", tt.name, len(resp.Data), resp.PagesFetched, tt.wantItems, tt.wantPages)
+		}
+		for i, tag := range resp.Data {
+			if want := "tag-" + strconv.Itoa(i); tag.ID != want {
+				t.Errorf("%s: item %d = %s, want %s", tt.name, i, tag.ID, want)
+			}
+		}
+
+		next := -1
+		if resp.Links.Next != "" {
+			next, _ = strconv.Atoi(cursorFromLink(resp.Links.Next, "page[after]"))
+		}
+		if next != tt.wantNext {
+			t.Errorf("%s: Links.Next resumes at %d, want %d", tt.name, next, tt.wantNext)
+		}
+		// Resuming must use the caller's page size, not the one shrunk to fit MaxItems
+		if next >= 0 && tt.opts.PageSize > 0 {
+			if size := cursorFromLink(resp.Links.Next, "page[size]"); size != strconv.Itoa(tt.opts.PageSize) {
+				t.Errorf("%s: Links.Next page size = %s, want %d", tt.name, size, tt.opts.PageSize)
+			}
+		}
+	}
+}
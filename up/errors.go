@@ -0,0 +1,96 @@
+package up
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sentinel errors matched by APIError through errors.Is
+var (
+	ErrValidation   = errors.New("up: validation failed")
+	ErrUnauthorized = errors.New("up: unauthorized")
+	ErrForbidden    = errors.New("up: forbidden")
+	ErrNotFound     = errors.New("up: not found")
+	ErrRateLimited  = errors.New("up: rate limited")
+	ErrServer       = errors.New("up: server error")
+)
+
+// APIError is returned when the Up API responds with an error status
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Errors holds every error object returned by the API, if the body could be decoded
+	Errors []ErrorObject
+	// Body is the raw response body
+	Body []byte
+	// Method and URL identify the request that failed
+	Method string
+	URL    string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+
+	if len(e.Errors) == 0 {
+		if body := strings.TrimSpace(string(e.Body)); body != "" {
+			fmt.Fprintf(&b, ": %s", truncate(body, 200))
+		}
+		return b.String()
+	}
+
+	for i, obj := range e.Errors {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "%s: %s", obj.Title, obj.Detail)
+	}
+	return b.String()
+}
+
+// Is allows an APIError to be matched against the sentinel errors by status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// As matches *ErrorResponse, which errors were returned as before APIError,
+// when the body was a JSON error document
+func (e *APIError) As(target any) bool {
+	t, ok := target.(**ErrorResponse)
+	if !ok || e.Errors == nil {
+		return false
+	}
+	*t = &ErrorResponse{Errors: e.Errors}
+	return true
+}
+
+// truncate shortens s to at most n bytes, marking the cut with an ellipsis.
+// The cut is moved back to a rune boundary so characters are not split.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
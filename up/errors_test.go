@@ -0,0 +1,81 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		sentinel error
+		// decoded reports whether the body is a JSON error document
+		decoded bool
+	}{
+		{http.StatusBadRequest, `{"errors":[{"status":"400","title":"Invalid Request","detail":"bad filter"}]}`, ErrValidation, true},
+		{http.StatusUnprocessableEntity, `{"errors":[]}`, ErrValidation, true},
+		{http.StatusUnauthorized, `{"errors":[{"title":"Not Authorized","detail":"bad token"}]}`, ErrUnauthorized, true},
+		{http.StatusForbidden, `forbidden`, ErrForbidden, false},
+		{http.StatusNotFound, `{"errors":[{"title":"Not Found","detail":"no such transaction"}]}`, ErrNotFound, true},
+		{http.StatusTooManyRequests, ``, ErrRateLimited, false},
+		{http.StatusBadGateway, `<html>bad gateway</html>`, ErrServer, false},
+	}
+	for _, tt := range tests {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		})
+		c := newTestClient(t, handler, WithRetryPolicy(NoRetry()))
+		_, _, err := c.Utility.Ping(context.Background())
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d: error %v is not an *APIError", tt.status, err)
+		}
+		if apiErr.StatusCode != tt.status || string(apiErr.Body) != tt.body || apiErr.Method != http.MethodGet {
+			t.Errorf("status %d: got %+v", tt.status, apiErr)
+		}
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("status %d: errors.Is(err, %v) = false", tt.status, tt.sentinel)
+		}
+
+		var errResp *ErrorResponse
+		if got := errors.As(err, &errResp); got != tt.decoded {
+			t.Errorf("status %d: errors.As(err, *ErrorResponse) = %v, want %v", tt.status, got, tt.decoded)
+		}
+		if tt.decoded && len(errResp.Errors) != len(apiErr.Errors) {
+			t.Errorf("status %d: ErrorResponse has %d errors, want %d", tt.status, len(errResp.Errors), len(apiErr.Errors))
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"abcdef", 3, "abc..."},
+		// "é" is two bytes and "€" three, the cut backs up to where they start
+		{"café au lait", 4, "caf..."},
+		{"5€ fee", 2, "5..."},
+		{"5€ fee", 3, "5..."},
+		{"5€ fee", 4, "5€..."},
+		{"€", 1, "..."},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) = %q is not valid UTF-8", tt.s, tt.n, got)
+		}
+	}
+}
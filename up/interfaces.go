@@ -0,0 +1,102 @@
+package up
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -destination=../upmock/mocks.go -package=upmock -typed . AccountsAPI,TransactionsAPI,TagsAPI,CategoriesAPI,WebhooksAPI,UtilityAPI
+
+// AccountsAPI is implemented by AccountsService
+type AccountsAPI interface {
+	List(ctx context.Context, opts *ListAccountsOptions) (*AccountListResponse, *http.Response, error)
+	Get(ctx context.Context, accountID string) (*Account, *http.Response, error)
+	All(ctx context.Context, opts *ListAccountsOptions) iter.Seq2[Account, error]
+	ListPage(ctx context.Context, opts *ListAccountsOptions) (*Page[Account], *http.Response, error)
+}
+
+// TransactionsAPI is implemented by TransactionsService
+type TransactionsAPI interface {
+	List(ctx context.Context, opts *ListTransactionsOptions) (*TransactionListResponse, *http.Response, error)
+	ListByAccount(ctx context.Context, accountID string, opts *ListTransactionsOptions) (*TransactionListResponse, *http.Response, error)
+	Get(ctx context.Context, transactionID string) (*Transaction, *http.Response, error)
+	All(ctx context.Context, opts *ListTransactionsOptions) iter.Seq2[Transaction, error]
+	AllByAccount(ctx context.Context, accountID string, opts *ListTransactionsOptions) iter.Seq2[Transaction, error]
+	ListPage(ctx context.Context, opts *ListTransactionsOptions) (*Page[Transaction], *http.Response, error)
+	ListPageByAccount(ctx context.Context, accountID string, opts *ListTransactionsOptions) (*Page[Transaction], *http.Response, error)
+}
+
+// TagsAPI is implemented by TagsService
+type TagsAPI interface {
+	List(ctx context.Context, opts *ListOptions) (*TagListResponse, *http.Response, error)
+	All(ctx context.Context, opts *ListOptions) iter.Seq2[Tag, error]
+	ListPage(ctx context.Context, opts *ListOptions) (*Page[Tag], *http.Response, error)
+	AddToTransaction(ctx context.Context, transactionID string, tagIDs []string) (*http.Response, error)
+	RemoveFromTransaction(ctx context.Context, transactionID string, tagIDs []string) (*http.Response, error)
+}
+
+// CategoriesAPI is implemented by CategoriesService
+type CategoriesAPI interface {
+	List(ctx context.Context, opts *ListCategoriesOptions) (*CategoryListResponse, *http.Response, error)
+	Get(ctx context.Context, categoryID string) (*Category, *http.Response, error)
+	UpdateTransactionCategory(ctx context.Context, transactionID string, categoryID string) (*http.Response, error)
+	RemoveTransactionCategory(ctx context.Context, transactionID string) (*http.Response, error)
+}
+
+// WebhooksAPI is implemented by WebhooksService
+type WebhooksAPI interface {
+	List(ctx context.Context, opts *ListOptions) (*WebhookListResponse, *http.Response, error)
+	Get(ctx context.Context, webhookID string) (*Webhook, *http.Response, error)
+	Create(ctx context.Context, url string, description *string) (*Webhook, *http.Response, error)
+	Delete(ctx context.Context, webhookID string) (*http.Response, error)
+	Ping(ctx context.Context, webhookID string) (*WebhookEvent, *http.Response, error)
+	ListLogs(ctx context.Context, webhookID string, opts *ListOptions) (*WebhookDeliveryLogListResponse, *http.Response, error)
+	All(ctx context.Context, opts *ListOptions) iter.Seq2[Webhook, error]
+	AllLogs(ctx context.Context, webhookID string, opts *ListOptions) iter.Seq2[WebhookDeliveryLog, error]
+	ListPage(ctx context.Context, opts *ListOptions) (*Page[Webhook], *http.Response, error)
+	ListLogsPage(ctx context.Context, webhookID string, opts *ListOptions) (*Page[WebhookDeliveryLog], *http.Response, error)
+	Reconcile(ctx context.Context, desired []WebhookSpec, opts *ReconcileOptions) (*WebhookPlan, error)
+	Health(ctx context.Context, webhookID string, since time.Time) (*WebhookHealth, error)
+	HealthAll(ctx context.Context, since time.Time) ([]*WebhookHealth, error)
+	Backfill(ctx context.Context, webhookID string, handle WebhookEventFunc, opts *BackfillOptions) (*BackfillResult, error)
+}
+
+// UtilityAPI is implemented by UtilityService
+type UtilityAPI interface {
+	Ping(ctx context.Context) (*PingResponse, *http.Response, error)
+}
+
+var (
+	_ AccountsAPI     = (*AccountsService)(nil)
+	_ TransactionsAPI = (*TransactionsService)(nil)
+	_ TagsAPI         = (*TagsService)(nil)
+	_ CategoriesAPI   = (*CategoriesService)(nil)
+	_ WebhooksAPI     = (*WebhooksService)(nil)
+	_ UtilityAPI      = (*UtilityService)(nil)
+)
+
+// ClientAPI groups the services behind interfaces so code can depend on it
+// instead of *Client and be given fakes or decorated services in tests. It
+// is used the same way as Client, e.g. api.Transactions.List(ctx, opts).
+type ClientAPI struct {
+	Accounts     AccountsAPI
+	Categories   CategoriesAPI
+	Tags         TagsAPI
+	Transactions TransactionsAPI
+	Webhooks     WebhooksAPI
+	Utility      UtilityAPI
+}
+
+// API returns the client's services as a ClientAPI
+func (c *Client) API() *ClientAPI {
+	return &ClientAPI{
+		Accounts:     c.Accounts,
+		Categories:   c.Categories,
+		Tags:         c.Tags,
+		Transactions: c.Transactions,
+		Webhooks:     c.Webhooks,
+		Utility:      c.Utility,
+	}
+}
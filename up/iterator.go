@@ -0,0 +1,101 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// iterate returns an iterator over every item of a paginated resource. Pages
+// are only requested as the caller consumes items, so breaking out of the
+// loop stops further requests. A failed request yields a single error.
+func iterate[T any](ctx context.Context, c *Client, initialURL string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		nextPageURL := initialURL
+
+		for nextPageURL != "" {
+			page, _, err := fetchPage[T](ctx, c, nextPageURL)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			nextPageURL = page.Links.Next
+		}
+	}
+}
+
+// errSeq returns an iterator yielding only err
+func errSeq[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// All returns an iterator over every account, fetching pages lazily
+func (s *AccountsService) All(ctx context.Context, opts *ListAccountsOptions) iter.Seq2[Account, error] {
+	ctx = withOperation(ctx, "accounts.list")
+	u, err := addOptions("accounts", opts)
+	if err != nil {
+		return errSeq[Account](err)
+	}
+	return iterate[Account](ctx, s.client, u)
+}
+
+// All returns an iterator over every transaction, fetching pages lazily
+func (s *TransactionsService) All(ctx context.Context, opts *ListTransactionsOptions) iter.Seq2[Transaction, error] {
+	ctx = withOperation(ctx, "transactions.list")
+	u, err := addOptions("transactions", opts)
+	if err != nil {
+		return errSeq[Transaction](err)
+	}
+	return iterate[Transaction](ctx, s.client, u)
+}
+
+// AllByAccount returns an iterator over every transaction of an account, fetching pages lazily
+func (s *TransactionsService) AllByAccount(ctx context.Context, accountID string, opts *ListTransactionsOptions) iter.Seq2[Transaction, error] {
+	ctx = withOperation(ctx, "transactions.list_by_account")
+	u, err := addOptions(fmt.Sprintf("accounts/%s/transactions", accountID), opts)
+	if err != nil {
+		return errSeq[Transaction](err)
+	}
+	return iterate[Transaction](ctx, s.client, u)
+}
+
+// All returns an iterator over every tag, fetching pages lazily
+func (s *TagsService) All(ctx context.Context, opts *ListOptions) iter.Seq2[Tag, error] {
+	ctx = withOperation(ctx, "tags.list")
+	u, err := addOptions("tags", opts)
+	if err != nil {
+		return errSeq[Tag](err)
+	}
+	return iterate[Tag](ctx, s.client, u)
+}
+
+// All returns an iterator over every webhook, fetching pages lazily
+func (s *WebhooksService) All(ctx context.Context, opts *ListOptions) iter.Seq2[Webhook, error] {
+	ctx = withOperation(ctx, "webhooks.list")
+	u, err := addOptions("webhooks", opts)
+	if err != nil {
+		return errSeq[Webhook](err)
+	}
+	return iterate[Webhook](ctx, s.client, u)
+}
+
+// AllLogs returns an iterator over every delivery log of a webhook, fetching pages lazily
+func (s *WebhooksService) AllLogs(ctx context.Context, webhookID string, opts *ListOptions) iter.Seq2[WebhookDeliveryLog, error] {
+	ctx = withOperation(ctx, "webhooks.list_logs")
+	u, err := addOptions(fmt.Sprintf("webhooks/%s/logs", webhookID), opts)
+	if err != nil {
+		return errSeq[WebhookDeliveryLog](err)
+	}
+	return iterate[WebhookDeliveryLog](ctx, s.client, u)
+}
@@ -0,0 +1,91 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// countingHandler counts the requests reaching h
+func countingHandler(calls *atomic.Int32, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		h.ServeHTTP(w, r)
+	})
+}
+
+func TestAllFetchesLazily(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, countingHandler(&calls, pagedHandler(7, tagItem)))
+
+	if calls.Load() != 0 {
+		t.Fatal("requests were sent before iterating")
+	}
+	var ids []string
+	for tag, err := range c.Tags.All(context.Background(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, tag.ID)
+		// Pages hold two tags, so the second page is only requested for the third tag
+		if want := int32(len(ids)+1) / 2; calls.Load() != want {
+			t.Errorf("after %d tags the server saw %d requests, want %d", len(ids), calls.Load(), want)
+		}
+	}
+	if len(ids) != 7 || ids[0] != "tag-0" || ids[6] != "tag-6" {
+		t.Errorf("tags = %v, want tag-0 to tag-6", ids)
+	}
+}
+
+func TestAllBreakStopsRequests(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, countingHandler(&calls, pagedHandler(7, tagItem)))
+
+	var n int
+	for _, err := range c.Tags.All(context.Background(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests after breaking on the third tag, want 2", got)
+	}
+}
+
+func TestAllYieldsErrorOnce(t *testing.T) {
+	var calls atomic.Int32
+	pages := pagedHandler(7, tagItem)
+	c := newTestClient(t, countingHandler(&calls, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[after]") == "4" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		pages.ServeHTTP(w, r)
+	})))
+
+	var (
+		items int
+		errs  []error
+	)
+	for tag, err := range c.Tags.All(context.Background(), nil) {
+		if err != nil {
+			errs = append(errs, err)
+			if tag.ID != "" {
+				t.Errorf("error yielded with tag %q, want the zero value", tag.ID)
+			}
+			continue
+		}
+		items++
+	}
+	if items != 4 || len(errs) != 1 || !errors.Is(errs[0], ErrNotFound) {
+		t.Errorf("got %d tags and errors %v, want 4 tags then one ErrNotFound", items, errs)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server saw %d requests, want 3", got)
+	}
+}
@@ -0,0 +1,56 @@
+package up
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts an ordinary function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to observe or modify requests and responses. It is
+// invoked once per attempt, so retried requests pass through it again.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware registers middlewares around the HTTP client. The first
+// middleware given is the outermost and sees requests first.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(c *Client) error {
+		c.middlewares = append(c.middlewares, mws...)
+		return nil
+	}
+}
+
+// buildDoer wraps the HTTP client in the registered middlewares
+func (c *Client) buildDoer() Doer {
+	var d Doer = c.client
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		d = c.middlewares[i](d)
+	}
+	return d
+}
+
+type operationKey struct{}
+
+// withOperation records the name of the service method making a request
+func withOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// OperationName returns the name of the service method that issued the
+// request, such as "transactions.list", or "" if it is unknown. Middlewares
+// can call it with req.Context().
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+	return name
+}
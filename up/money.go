@@ -0,0 +1,354 @@
+package up
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when combining amounts in different currencies
+var ErrCurrencyMismatch = errors.New("up: currency mismatch")
+
+// currencyExponents lists currencies whose minor unit is not hundredths
+var currencyExponents = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0,
+	"KRW": 0, "KWD": 3, "OMR": 3, "TND": 3, "VND": 0,
+}
+
+// Locale describes how a region writes amounts of money
+type Locale struct {
+	// Decimal separates the fraction and Group separates thousands
+	Decimal, Group string
+	// SymbolAfter writes the symbol after the number, e.g. "1.234,56 €"
+	SymbolAfter bool
+	// Symbols maps currency codes to symbols. Other currencies are written
+	// with their code.
+	Symbols map[string]string
+}
+
+// Locales for Format. String and ParseMoney use LocaleAU.
+var (
+	LocaleAU = Locale{Decimal: ".", Group: ",", Symbols: map[string]string{
+		"AUD": "$", "CAD": "CA$", "EUR": "€", "GBP": "£", "HKD": "HK$",
+		"JPY": "¥", "NZD": "NZ$", "SGD": "S$", "USD": "US$",
+	}}
+	LocaleNZ = Locale{Decimal: ".", Group: ",", Symbols: map[string]string{
+		"AUD": "A$", "CAD": "CA$", "EUR": "€", "GBP": "£", "HKD": "HK$",
+		"JPY": "¥", "NZD": "$", "SGD": "S$", "USD": "US$",
+	}}
+	LocaleUS = Locale{Decimal: ".", Group: ",", Symbols: map[string]string{
+		"AUD": "A$", "CAD": "CA$", "EUR": "€", "GBP": "£", "HKD": "HK$",
+		"JPY": "¥", "NZD": "NZ$", "SGD": "SGD", "USD": "$",
+	}}
+	LocaleGB = Locale{Decimal: ".", Group: ",", Symbols: map[string]string{
+		"AUD": "A$", "CAD": "CA$", "EUR": "€", "GBP": "£", "HKD": "HK$",
+		"JPY": "JP¥", "NZD": "NZ$", "SGD": "SGD", "USD": "US$",
+	}}
+	LocaleDE = Locale{Decimal: ",", Group: ".", SymbolAfter: true, Symbols: map[string]string{
+		"AUD": "AU$", "CAD": "CA$", "EUR": "€", "GBP": "£", "HKD": "HK$",
+		"JPY": "¥", "NZD": "NZ$", "SGD": "SGD", "USD": "$",
+	}}
+)
+
+// Money is an exact monetary amount in a single currency, stored as an
+// integer number of the currency's minor units
+type Money struct {
+	currency  string
+	baseUnits int64
+	exponent  int
+}
+
+// NewMoney returns an amount of baseUnits minor units (e.g. cents) of currency
+func NewMoney(currency string, baseUnits int64) Money {
+	currency = strings.ToUpper(currency)
+	return Money{currency: currency, baseUnits: baseUnits, exponent: currencyExponent(currency)}
+}
+
+// ParseMoney parses user input such as "12.34", "-$1,234.5" or "(12.34)" as an amount of currency
+func ParseMoney(currency, s string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	exponent := currencyExponent(currency)
+
+	units, err := parseDecimal(s, currency, exponent)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{currency: currency, baseUnits: units, exponent: exponent}, nil
+}
+
+// Money converts the API representation into a Money value. The number of
+// decimal places is taken from Value so unusual currencies round-trip exactly.
+func (m MoneyObject) Money() (Money, error) {
+	currency := strings.ToUpper(m.CurrencyCode)
+	exponent := currencyExponent(currency)
+	if i := strings.IndexByte(m.Value, '.'); i >= 0 {
+		exponent = len(m.Value) - i - 1
+	}
+
+	units, err := parseDecimal(m.Value, currency, exponent)
+	if err != nil {
+		return Money{}, err
+	}
+	if units != m.ValueInBaseUnits {
+		return Money{}, fmt.Errorf("money: value %q does not match %d base units", m.Value, m.ValueInBaseUnits)
+	}
+	return Money{currency: currency, baseUnits: units, exponent: exponent}, nil
+}
+
+// MoneyObject converts m back into the API representation
+func (m Money) MoneyObject() MoneyObject {
+	return MoneyObject{
+		CurrencyCode:     m.currency,
+		Value:            m.Decimal(),
+		ValueInBaseUnits: m.baseUnits,
+	}
+}
+
+// Currency returns the ISO 4217 currency code
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// BaseUnits returns the amount in minor units, e.g. cents
+func (m Money) BaseUnits() int64 {
+	return m.baseUnits
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.baseUnits == 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.baseUnits < 0
+}
+
+// Add returns m + o
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	sum := m.baseUnits + o.baseUnits
+	if (sum > m.baseUnits) != (o.baseUnits > 0) {
+		return Money{}, fmt.Errorf("money: overflow adding %s and %s", m, o)
+	}
+	m.baseUnits = sum
+	return m, nil
+}
+
+// Sub returns m - o
+func (m Money) Sub(o Money) (Money, error) {
+	neg, err := o.Neg()
+	if err != nil {
+		return Money{}, fmt.Errorf("money: overflow subtracting %s from %s", o, m)
+	}
+	return m.Add(neg)
+}
+
+// Neg returns -m. It fails only for the most negative amount, which has no
+// positive counterpart.
+func (m Money) Neg() (Money, error) {
+	if m.baseUnits == math.MinInt64 {
+		return Money{}, fmt.Errorf("money: overflow negating %s", m)
+	}
+	m.baseUnits = -m.baseUnits
+	return m, nil
+}
+
+// Abs returns the absolute value of m, failing like Neg
+func (m Money) Abs() (Money, error) {
+	if m.baseUnits < 0 {
+		return m.Neg()
+	}
+	return m, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether m is less than, equal to
+// or greater than o
+func (m Money) Compare(o Money) (int, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.baseUnits < o.baseUnits:
+		return -1, nil
+	case m.baseUnits > o.baseUnits:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m Money) checkCurrency(o Money) error {
+	if m.currency != o.currency || m.exponent != o.exponent {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	return nil
+}
+
+// Decimal returns the amount as a plain decimal string, as used by the API (e.g. "-12.34")
+func (m Money) Decimal() string {
+	return formatDecimal(m.baseUnits, m.exponent, ".", "")
+}
+
+// String formats the amount for an Australian audience, e.g. "-$1,234.56"
+// or "NZ$5.00". See Format for other locales.
+func (m Money) String() string {
+	return m.Format(LocaleAU)
+}
+
+// Format formats the amount as written in loc, e.g. "-US$1,234.56" for
+// LocaleAU or "-1.234,56 $" for LocaleDE. Currencies without a symbol in loc
+// are written with their code, and the zero Money as a bare "0".
+func (m Money) Format(loc Locale) string {
+	number := formatDecimal(m.baseUnits, m.exponent, loc.Decimal, loc.Group)
+	if m.currency == "" {
+		return number
+	}
+
+	symbol, ok := loc.Symbols[m.currency]
+	if !ok {
+		symbol = m.currency
+	}
+	if loc.SymbolAfter {
+		return number + " " + symbol
+	}
+	if !ok {
+		symbol += " "
+	}
+	if digits, neg := strings.CutPrefix(number, "-"); neg {
+		return "-" + symbol + digits
+	}
+	return symbol + number
+}
+
+// MarshalJSON encodes m in the same shape as MoneyObject
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.MoneyObject())
+}
+
+// UnmarshalJSON decodes m from the MoneyObject shape
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var obj MoneyObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	v, err := obj.Money()
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+func currencyExponent(currency string) int {
+	if e, ok := currencyExponents[currency]; ok {
+		return e
+	}
+	return 2
+}
+
+// formatDecimal renders units with exponent decimal places after point,
+// grouping thousands with group unless it is empty
+func formatDecimal(units int64, exponent int, point, group string) string {
+	neg := units < 0
+	digits := strconv.FormatUint(absUint(units), 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+	if group != "" {
+		whole = groupThousands(whole, group)
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(whole)
+	if exponent > 0 {
+		b.WriteString(point)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+func groupThousands(s, sep string) string {
+	if len(s) <= 3 {
+		return s
+	}
+	var b strings.Builder
+	lead := len(s) % 3
+	if lead > 0 {
+		b.WriteString(s[:lead])
+	}
+	for i := lead; i < len(s); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
+
+func absUint(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
+
+// parseDecimal parses a decimal amount into minor units. It accepts an optional
+// sign or surrounding parentheses, the currency's symbol or code, and commas
+// as thousands separators.
+func parseDecimal(s, currency string, exponent int) (int64, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+
+	neg := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		neg = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if strings.HasPrefix(s, "-") {
+		neg = !neg
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	s = strings.TrimSpace(strings.TrimPrefix(s, currency))
+	if symbol, ok := LocaleAU.Symbols[currency]; ok {
+		s = strings.TrimPrefix(s, symbol)
+	}
+	s = strings.ReplaceAll(s, ",", "")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("money: invalid amount %q", orig)
+	}
+	if len(frac) > exponent {
+		return 0, fmt.Errorf("money: %q has more than %d decimal places for %s", orig, exponent, currency)
+	}
+	frac += strings.Repeat("0", exponent-len(frac))
+
+	digits := whole + frac
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("money: invalid amount %q", orig)
+		}
+	}
+
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("money: invalid amount %q: %w", orig, err)
+	}
+	if neg {
+		units = -units
+	}
+	return units, nil
+}
@@ -0,0 +1,173 @@
+package up
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		currency, in string
+		want         int64
+		wantErr      bool
+	}{
+		{"AUD", "12.34", 1234, false},
+		{"AUD", "12", 1200, false},
+		{"AUD", "12.3", 1230, false},
+		{"AUD", ".5", 50, false},
+		{"AUD", "-$1,234.5", -123450, false},
+		{"AUD", "$-1.00", 0, true},
+		{"AUD", "(12.34)", -1234, false},
+		{"AUD", "(-12.34)", 1234, false},
+		{"AUD", "+7.00", 700, false},
+		{"AUD", " AUD 3.10 ", 310, false},
+		{"aud", "1.00", 100, false},
+		{"USD", "US$2.50", 250, false},
+		{"JPY", "¥1,000", 1000, false},
+		{"KWD", "1.234", 1234, false},
+		{"AUD", "1.234", 0, true},
+		{"JPY", "1.5", 0, true},
+		{"AUD", "", 0, true},
+		{"AUD", ".", 0, true},
+		{"AUD", "abc", 0, true},
+		{"AUD", "1.2.3", 0, true},
+		{"AUD", "1e3", 0, true},
+		{"AUD", "92233720368547758.08", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.currency, tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMoney(%q, %q) error = %v, want error %v", tt.currency, tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.BaseUnits() != tt.want {
+			t.Errorf("ParseMoney(%q, %q) = %d base units, want %d", tt.currency, tt.in, got.BaseUnits(), tt.want)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		m    Money
+		loc  Locale
+		want string
+	}{
+		{NewMoney("AUD", -123456), LocaleAU, "-$1,234.56"},
+		{NewMoney("AUD", 5), LocaleAU, "$0.05"},
+		{NewMoney("NZD", 500), LocaleAU, "NZ$5.00"},
+		{NewMoney("NZD", 500), LocaleNZ, "$5.00"},
+		{NewMoney("AUD", 500), LocaleNZ, "A$5.00"},
+		{NewMoney("USD", -123456789), LocaleUS, "-$1,234,567.89"},
+		{NewMoney("JPY", 1000), LocaleAU, "¥1,000"},
+		{NewMoney("KWD", 1234), LocaleAU, "KWD 1.234"},
+		{NewMoney("CHF", -1050), LocaleAU, "-CHF 10.50"},
+		{NewMoney("EUR", -123456), LocaleDE, "-1.234,56 €"},
+		{NewMoney("CHF", 1050), LocaleDE, "10,50 CHF"},
+		{NewMoney("AUD", math.MinInt64), LocaleAU, "-$92,233,720,368,547,758.08"},
+		{Money{}, LocaleAU, "0"},
+		{Money{}, LocaleDE, "0"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Format(tt.loc); got != tt.want {
+			t.Errorf("Format(%s %d) = %q, want %q", tt.m.Currency(), tt.m.BaseUnits(), got, tt.want)
+		}
+	}
+
+	if got := NewMoney("AUD", -1234).String(); got != "-$12.34" {
+		t.Errorf("String() = %q, want -$12.34", got)
+	}
+	if got := NewMoney("AUD", -1234).Decimal(); got != "-12.34" {
+		t.Errorf("Decimal() = %q, want -12.34", got)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := NewMoney("AUD", 1050), NewMoney("AUD", -2075)
+
+	if sum, err := a.Add(b); err != nil || sum.BaseUnits() != -1025 {
+		t.Errorf("Add = %v, %v, want -1025", sum.BaseUnits(), err)
+	}
+	if diff, err := a.Sub(b); err != nil || diff.BaseUnits() != 3125 {
+		t.Errorf("Sub = %v, %v, want 3125", diff.BaseUnits(), err)
+	}
+	if neg, err := b.Neg(); err != nil || neg.BaseUnits() != 2075 {
+		t.Errorf("Neg = %v, %v, want 2075", neg.BaseUnits(), err)
+	}
+	if abs, err := b.Abs(); err != nil || abs.BaseUnits() != 2075 {
+		t.Errorf("Abs = %v, %v, want 2075", abs.BaseUnits(), err)
+	}
+	if c, err := a.Compare(b); err != nil || c != 1 {
+		t.Errorf("Compare = %v, %v, want 1", c, err)
+	}
+
+	usd := NewMoney("USD", 100)
+	if _, err := a.Add(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies returned %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := a.Compare(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Compare across currencies returned %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestMoneyOverflow(t *testing.T) {
+	maxAUD, minAUD := NewMoney("AUD", math.MaxInt64), NewMoney("AUD", math.MinInt64)
+	one := NewMoney("AUD", 1)
+
+	if _, err := maxAUD.Add(one); err == nil {
+		t.Error("MaxInt64 + 1 did not fail")
+	}
+	if _, err := minAUD.Sub(one); err == nil {
+		t.Error("MinInt64 - 1 did not fail")
+	}
+	if _, err := one.Sub(minAUD); err == nil {
+		t.Error("1 - MinInt64 did not fail")
+	}
+	if _, err := minAUD.Neg(); err == nil {
+		t.Error("-MinInt64 did not fail")
+	}
+	if _, err := minAUD.Abs(); err == nil {
+		t.Error("Abs(MinInt64) did not fail")
+	}
+	if got, err := maxAUD.Neg(); err != nil || got.BaseUnits() != -math.MaxInt64 {
+		t.Errorf("-MaxInt64 = %v, %v", got.BaseUnits(), err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []string{
+		`{"currencyCode":"AUD","value":"-12.34","valueInBaseUnits":-1234}`,
+		`{"currencyCode":"AUD","value":"0.00","valueInBaseUnits":0}`,
+		`{"currencyCode":"JPY","value":"1000","valueInBaseUnits":1000}`,
+		`{"currencyCode":"KWD","value":"1.234","valueInBaseUnits":1234}`,
+		// Decimal places come from the value, not the currency
+		`{"currencyCode":"XYZ","value":"1.2345","valueInBaseUnits":12345}`,
+	}
+	for _, in := range tests {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err != nil {
+			t.Errorf("Unmarshal(%s): %v", in, err)
+			continue
+		}
+		out, err := json.Marshal(m)
+		if err != nil {
+			t.Errorf("Marshal(%s): %v", in, err)
+			continue
+		}
+		if string(out) != in {
+			t.Errorf("round trip of %s = %s", in, out)
+		}
+	}
+
+	for _, in := range []string{
+		`{"currencyCode":"AUD","value":"12.34","valueInBaseUnits":1200}`,
+		`{"currencyCode":"AUD","value":"twelve","valueInBaseUnits":1200}`,
+		`"12.34"`,
+	} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err == nil {
+			t.Errorf("Unmarshal(%s) did not fail", in)
+		}
+	}
+}
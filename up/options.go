@@ -0,0 +1,78 @@
+package up
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a Client created with New
+type Option func(*Client) error
+
+// WithBaseURL sets the URL that API paths are resolved against, for example
+// to point the client at a local test server or an egress proxy
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		if !strings.HasSuffix(rawURL, "/") {
+			rawURL += "/"
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid base URL %q: scheme and host are required", rawURL)
+		}
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests. A nil client
+// leaves the default in place. WithTimeout and WithTransport apply to a copy
+// of it whatever order the options are given in.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient != nil {
+			c.client = httpClient
+		}
+		return nil
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("invalid timeout %s", timeout)
+		}
+		c.timeout = &timeout
+		return nil
+	}
+}
+
+// WithTransport sets the round tripper used by the HTTP client
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) error {
+		c.transport = transport
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy. A nil policy disables retries.
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.SetRetryPolicy(p)
+		return nil
+	}
+}
@@ -0,0 +1,51 @@
+package up
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOptionOrder(t *testing.T) {
+	transport := &http.Transport{}
+	orders := map[string][]Option{
+		"client first": {WithHTTPClient(&http.Client{}), WithTimeout(5 * time.Second), WithTransport(transport)},
+		"client last":  {WithTimeout(5 * time.Second), WithTransport(transport), WithHTTPClient(&http.Client{})},
+	}
+	for name, opts := range orders {
+		c, err := New("token", opts...)
+		if err != nil {
+			t.Fatalf("%s: New: %v", name, err)
+		}
+		if c.client.Timeout != 5*time.Second {
+			t.Errorf("%s: timeout = %s, want 5s", name, c.client.Timeout)
+		}
+		if c.client.Transport != transport {
+			t.Errorf("%s: transport was not applied", name)
+		}
+	}
+}
+
+func TestWithHTTPClientNotModified(t *testing.T) {
+	hc := &http.Client{}
+	c, err := New("token", WithHTTPClient(hc), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc.Timeout != 0 {
+		t.Errorf("caller's client timeout changed to %s", hc.Timeout)
+	}
+	if c.client == hc {
+		t.Error("client uses the caller's http.Client instead of a copy")
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	c, err := New("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.client.Timeout != defaultTimeout {
+		t.Errorf("timeout = %s, want %s", c.client.Timeout, defaultTimeout)
+	}
+}
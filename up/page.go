@@ -0,0 +1,119 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Page is a single page of a paginated list. NextCursor and PrevCursor can be
+// persisted and passed back as ListOptions.After and ListOptions.Before, with
+// the same filters, to resume from this point later.
+type Page[T any] struct {
+	ListResponse[T]
+	NextCursor string `json:"-"`
+	PrevCursor string `json:"-"`
+}
+
+// HasNext reports whether there is a page after this one
+func (p *Page[T]) HasNext() bool {
+	return p.Links.Next != ""
+}
+
+// HasPrev reports whether there is a page before this one
+func (p *Page[T]) HasPrev() bool {
+	return p.Links.Prev != ""
+}
+
+// fetchPage requests a single page of a paginated resource
+func fetchPage[T any](ctx context.Context, c *Client, pageURL string) (*Page[T], *http.Response, error) {
+	req, err := c.newRequest("GET", pageURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var page Page[T]
+	resp, err := c.do(ctx, req, &page)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	page.PagesFetched = 1
+	page.NextCursor = cursorFromLink(page.Links.Next, "page[after]")
+	page.PrevCursor = cursorFromLink(page.Links.Prev, "page[before]")
+
+	return &page, resp, nil
+}
+
+// cursorFromLink extracts the cursor query parameter from a pagination link
+func cursorFromLink(link, param string) string {
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get(param)
+}
+
+// ListPage returns a single page of accounts
+func (s *AccountsService) ListPage(ctx context.Context, opts *ListAccountsOptions) (*Page[Account], *http.Response, error) {
+	ctx = withOperation(ctx, "accounts.list")
+	u, err := addOptions("accounts", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fetchPage[Account](ctx, s.client, u)
+}
+
+// ListPage returns a single page of transactions
+func (s *TransactionsService) ListPage(ctx context.Context, opts *ListTransactionsOptions) (*Page[Transaction], *http.Response, error) {
+	ctx = withOperation(ctx, "transactions.list")
+	u, err := addOptions("transactions", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fetchPage[Transaction](ctx, s.client, u)
+}
+
+// ListPageByAccount returns a single page of transactions for a specific account
+func (s *TransactionsService) ListPageByAccount(ctx context.Context, accountID string, opts *ListTransactionsOptions) (*Page[Transaction], *http.Response, error) {
+	ctx = withOperation(ctx, "transactions.list_by_account")
+	u, err := addOptions(fmt.Sprintf("accounts/%s/transactions", accountID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fetchPage[Transaction](ctx, s.client, u)
+}
+
+// ListPage returns a single page of tags
+func (s *TagsService) ListPage(ctx context.Context, opts *ListOptions) (*Page[Tag], *http.Response, error) {
+	ctx = withOperation(ctx, "tags.list")
+	u, err := addOptions("tags", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fetchPage[Tag](ctx, s.client, u)
+}
+
+// ListPage returns a single page of webhooks
+func (s *WebhooksService) ListPage(ctx context.Context, opts *ListOptions) (*Page[Webhook], *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.list")
+	u, err := addOptions("webhooks", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fetchPage[Webhook](ctx, s.client, u)
+}
+
+// ListLogsPage returns a single page of delivery logs for a webhook
+func (s *WebhooksService) ListLogsPage(ctx context.Context, webhookID string, opts *ListOptions) (*Page[WebhookDeliveryLog], *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.list_logs")
+	u, err := addOptions(fmt.Sprintf("webhooks/%s/logs", webhookID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fetchPage[WebhookDeliveryLog](ctx, s.client, u)
+}
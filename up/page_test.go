@@ -0,0 +1,23 @@
+package up
+
+import "testing"
+
+func TestListPage(t *testing.T) {
+	c := newTestClient(t, pagedHandler(3, tagItem))
+
+	first, _, err := c.Tags.ListPage(t.Context(), &ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Data) != 2 || first.PagesFetched != 1 || !first.HasNext() || first.NextCursor != "2" {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	second, _, err := c.Tags.ListPage(t.Context(), &ListOptions{PageSize: 2, After: first.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Data) != 1 || second.Data[0].ID != "tag-2" || second.HasNext() {
+		t.Errorf("second page = %+v", second)
+	}
+}
@@ -0,0 +1,131 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that slows down when the API reports it is
+// being rate limited and recovers gradually as requests succeed
+type rateLimiter struct {
+	mu       sync.Mutex
+	baseRate float64 // tokens per second when healthy
+	minRate  float64
+	rate     float64 // current tokens per second
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		baseRate: requestsPerSecond,
+		minRate:  requestsPerSecond / 16,
+		rate:     requestsPerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+
+	// Reserve a token, possibly going into debt, and wait for the debt to be repaid
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := sleep(ctx, delay); err != nil {
+		// Give the reservation back so other callers are not penalised
+		l.mu.Lock()
+		l.tokens = math.Min(l.tokens+1, l.burst)
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = math.Min(l.tokens+elapsed*l.rate, l.burst)
+}
+
+// slowDown halves the request rate after a 429 response
+func (l *rateLimiter) slowDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.rate = math.Max(l.rate/2, l.minRate)
+}
+
+// speedUp moves the request rate back towards its configured value after a success
+func (l *rateLimiter) speedUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate < l.baseRate {
+		l.refill(time.Now())
+		l.rate = math.Min(l.rate+l.baseRate/10, l.baseRate)
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond on average, allowing
+// bursts of up to burst requests. The rate is reduced automatically when the
+// API responds with 429 Too Many Requests.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("invalid rate limit %v", requestsPerSecond)
+		}
+		c.limiter = newRateLimiter(requestsPerSecond, burst)
+		return nil
+	}
+}
+
+// WithMaxInFlight caps the number of requests the client sends concurrently
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("invalid max in flight %d", n)
+		}
+		c.inFlight = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// acquire waits for the rate limiter and a concurrency slot before a request is sent
+func (c *Client) acquire(ctx context.Context) error {
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// release frees the concurrency slot taken by acquire
+func (c *Client) release() {
+	if c.inFlight != nil {
+		<-c.inFlight
+	}
+}
@@ -0,0 +1,164 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxInFlight(t *testing.T) {
+	var current, peak atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	})
+	c := newTestClient(t, handler, WithMaxInFlight(2))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := c.Utility.Ping(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got < 1 || got > 2 {
+		t.Errorf("peak concurrent requests = %d, want at most 2", got)
+	}
+	if len(c.inFlight) != 0 {
+		t.Errorf("%d slots still held after every request finished", len(c.inFlight))
+	}
+}
+
+func TestRateLimitAdaptsTo429(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, failingHandler(&calls, 2, http.StatusTooManyRequests),
+		WithRateLimit(100, 10), WithRetryPolicy(fastRetry(1)))
+
+	for _, want := range []float64{50, 25} {
+		if _, _, err := c.Utility.Ping(context.Background()); !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("Ping returned %v, want ErrRateLimited", err)
+		}
+		if c.limiter.rate != want {
+			t.Errorf("rate after a 429 = %v, want %v", c.limiter.rate, want)
+		}
+	}
+
+	// Successes recover a tenth of the configured rate each
+	for _, want := range []float64{35, 45} {
+		if _, _, err := c.Utility.Ping(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if c.limiter.rate != want {
+			t.Errorf("rate after a success = %v, want %v", c.limiter.rate, want)
+		}
+	}
+}
+
+func TestRateLimiterBounds(t *testing.T) {
+	l := newRateLimiter(16, 1)
+	for range 10 {
+		l.slowDown()
+	}
+	if l.rate != 1 {
+		t.Errorf("rate after repeated 429s = %v, want the floor of 1", l.rate)
+	}
+	for range 20 {
+		l.speedUp()
+	}
+	if l.rate != 16 {
+		t.Errorf("rate after recovering = %v, want 16", l.rate)
+	}
+}
+
+func TestRateLimiterPaces(t *testing.T) {
+	l := newRateLimiter(50, 2)
+	start := time.Now()
+	for range 4 {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The burst covers two requests, the other two wait 20ms each
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("4 requests at 50/s with a burst of 2 took %s, want about 40ms", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := newRateLimiter(0.01, 1)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait returned %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("wait took %s to notice the cancelled context", elapsed)
+	}
+	// The reservation is given back
+	if l.tokens < -0.01 {
+		t.Errorf("tokens = %v after a cancelled wait, want the reservation returned", l.tokens)
+	}
+}
+
+func TestAcquireCancelled(t *testing.T) {
+	c, err := New("token", WithMaxInFlight(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The only slot is taken, so acquire blocks until ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.acquire(ctx) }()
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("acquire returned %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire did not return after ctx was cancelled")
+	}
+
+	c.release()
+	if err := c.acquire(context.Background()); err != nil {
+		t.Errorf("acquire after release: %v", err)
+	}
+}
+
+func TestRateLimitOptionsValidate(t *testing.T) {
+	for name, opt := range map[string]Option{
+		"zero rate":          WithRateLimit(0, 1),
+		"negative rate":      WithRateLimit(-1, 1),
+		"zero max in flight": WithMaxInFlight(0),
+	} {
+		if _, err := New("token", opt); err == nil {
+			t.Errorf("%s: New succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry retries quickly so tests do not wait on backoff
+func fastRetry(attempts int) *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+// failingHandler responds with status to the first failures requests and
+// with an empty JSON object after that
+func failingHandler(calls *atomic.Int32, failures int32, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	for _, status := range []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	} {
+		var calls atomic.Int32
+		c := newTestClient(t, failingHandler(&calls, 2, status), WithRetryPolicy(fastRetry(3)))
+
+		if _, _, err := c.Utility.Ping(context.Background()); err != nil {
+			t.Errorf("status %d: Ping returned error: %v", status, err)
+		}
+		if got := calls.Load(); got != 3 {
+			t.Errorf("status %d: server saw %d requests, want 3", status, got)
+		}
+	}
+}
+
+func TestRetryGivesUpAtMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, failingHandler(&calls, 100, http.StatusServiceUnavailable), WithRetryPolicy(fastRetry(4)))
+
+	_, _, err := c.Utility.Ping(context.Background())
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("Ping returned %v, want ErrServer", err)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("server saw %d requests, want 4", got)
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, failingHandler(&calls, 100, http.StatusNotFound), WithRetryPolicy(fastRetry(3)))
+
+	if _, _, err := c.Utility.Ping(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Ping returned %v, want ErrNotFound", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	tests := []struct {
+		policy *RetryPolicy
+		want   int32
+		// status is the APIError status expected, zero for success
+		status int
+	}{
+		{fastRetry(3), 1, http.StatusServiceUnavailable},
+		{&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}, 2, 0},
+	}
+	for _, tt := range tests {
+		var (
+			calls  atomic.Int32
+			mu     sync.Mutex
+			bodies []string
+		)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			bodies = append(bodies, string(b))
+			mu.Unlock()
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{}`))
+		})
+		c := newTestClient(t, handler, WithRetryPolicy(tt.policy))
+
+		req, err := c.newRequest(http.MethodPost, "webhooks", map[string]string{"url": "https://example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.do(context.Background(), req, nil)
+
+		var apiErr *APIError
+		switch {
+		case tt.status == 0 && err != nil:
+			t.Errorf("RetryNonIdempotent=%v: do returned %v, want success", tt.policy.RetryNonIdempotent, err)
+		case tt.status != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status || apiErr.Method != http.MethodPost):
+			t.Errorf("RetryNonIdempotent=%v: do returned %v, want a POST APIError with status %d", tt.policy.RetryNonIdempotent, err, tt.status)
+		}
+		if got := calls.Load(); got != tt.want {
+			t.Errorf("RetryNonIdempotent=%v: server saw %d requests, want %d", tt.policy.RetryNonIdempotent, got, tt.want)
+		}
+		// Every attempt must carry the full body, replayed through GetBody
+		mu.Lock()
+		for i, b := range bodies {
+			if !strings.Contains(b, `"url":"https://example.com"`) {
+				t.Errorf("RetryNonIdempotent=%v: attempt %d body = %q", tt.policy.RetryNonIdempotent, i+1, b)
+			}
+		}
+		mu.Unlock()
+	}
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, failingHandler(&calls, 100, http.StatusServiceUnavailable),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := c.Utility.Ping(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Ping returned %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Ping took %s after the context expired", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	// Without the header the client would back off for an hour
+	c := newTestClient(t, handler, WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, _, err := c.Utility.Ping(ctx); err != nil {
+		t.Fatalf("Ping returned error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2", got)
+	}
+}
+
+func TestBackoffRetryAfter(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Minute}
+	header := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{v}}}
+	}
+
+	if got := p.backoff(header("7"), 1); got != 7*time.Second {
+		t.Errorf("Retry-After in seconds: backoff = %s, want 7s", got)
+	}
+
+	// HTTP dates have a resolution of one second
+	date := time.Now().Add(20 * time.Second).UTC().Format(http.TimeFormat)
+	if got := p.backoff(header(date), 1); got < 18*time.Second || got > 20*time.Second {
+		t.Errorf("Retry-After as an HTTP date: backoff = %s, want about 20s", got)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	if got := p.backoff(header(past), 1); got != 0 {
+		t.Errorf("Retry-After in the past: backoff = %s, want 0", got)
+	}
+
+	if got := p.backoff(header("3600"), 1); got != time.Minute {
+		t.Errorf("Retry-After above MaxBackoff: backoff = %s, want 1m", got)
+	}
+
+	// An unparseable header falls back to the exponential backoff
+	if got := p.backoff(header("soon"), 1); got > time.Millisecond {
+		t.Errorf("invalid Retry-After: backoff = %s, want at most 1ms", got)
+	}
+}
+
+func TestBackoffExponential(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for range 20 {
+			if got := p.backoff(nil, attempt); got < want/2 || got > want {
+				t.Errorf("attempt %d: backoff = %s, want between %s and %s", attempt, got, want/2, want)
+			}
+		}
+	}
+}
@@ -24,10 +24,7 @@ type TagRelationships struct {
 }
 
 // TagListResponse represents the response from listing tags
-type TagListResponse struct {
-	Data  []Tag `json:"data"`
-	Links Links `json:"links"`
-}
+type TagListResponse = ListResponse[Tag]
 
 // TagInputResource represents a tag input for adding/removing tags
 type TagInputResource struct {
@@ -42,6 +39,7 @@ type UpdateTransactionTagsRequest struct {
 
 // List returns a list of all tags
 func (s *TagsService) List(ctx context.Context, opts *ListOptions) (*TagListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "tags.list")
 	u := "tags"
 	if opts != nil {
 		var err error
@@ -51,22 +49,12 @@ func (s *TagsService) List(ctx context.Context, opts *ListOptions) (*TagListResp
 		}
 	}
 
-	req, err := s.client.newRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var tags TagListResponse
-	resp, err := s.client.do(ctx, req, &tags)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return &tags, resp, nil
+	return paginate[Tag](ctx, s.client, u, opts)
 }
 
 // AddToTransaction adds tags to a transaction
 func (s *TagsService) AddToTransaction(ctx context.Context, transactionID string, tagIDs []string) (*http.Response, error) {
+	ctx = withOperation(ctx, "tags.add_to_transaction")
 	u := fmt.Sprintf("transactions/%s/relationships/tags", transactionID)
 
 	tags := make([]TagInputResource, len(tagIDs))
@@ -87,6 +75,7 @@ func (s *TagsService) AddToTransaction(ctx context.Context, transactionID string
 
 // RemoveFromTransaction removes tags from a transaction
 func (s *TagsService) RemoveFromTransaction(ctx context.Context, transactionID string, tagIDs []string) (*http.Response, error) {
+	ctx = withOperation(ctx, "tags.remove_from_transaction")
 	u := fmt.Sprintf("transactions/%s/relationships/tags", transactionID)
 
 	tags := make([]TagInputResource, len(tagIDs))
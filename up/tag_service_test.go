@@ -0,0 +1,41 @@
+package up
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestTagsListMergesPages(t *testing.T) {
+	c := newTestClient(t, pagedHandler(5, tagItem))
+
+	resp, _, err := c.Tags.List(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.PagesFetched != 3 || resp.Links.Next != "" {
+		t.Errorf("PagesFetched = %d, Links.Next = %q, want 3 pages and no next link", resp.PagesFetched, resp.Links.Next)
+	}
+	if len(resp.Data) != 5 {
+		t.Fatalf("got %d tags, want 5", len(resp.Data))
+	}
+	for i, tag := range resp.Data {
+		if want := "tag-" + strconv.Itoa(i); tag.ID != want {
+			t.Errorf("tag %d = %s, want %s", i, tag.ID, want)
+		}
+	}
+}
+
+func TestTagsListMaxPages(t *testing.T) {
+	c := newTestClient(t, pagedHandler(5, tagItem))
+
+	resp, _, err := c.Tags.List(t.Context(), &ListOptions{MaxPages: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data) != 4 || resp.PagesFetched != 2 {
+		t.Errorf("got %d tags in %d pages, want 4 in 2", len(resp.Data), resp.PagesFetched)
+	}
+	if got := cursorFromLink(resp.Links.Next, "page[after]"); got != "4" {
+		t.Errorf("Links.Next = %q, want it to resume at 4", resp.Links.Next)
+	}
+}
@@ -0,0 +1,267 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ErrNoToken is returned by token sources that have no token to give
+var ErrNoToken = errors.New("up: no token")
+
+// TokenSource supplies the personal access token sent with each request.
+// Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx)
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// WithTokenSource fetches the token from src for each API call instead of
+// using the token given to New. Wrap slow sources, such as CommandToken,
+// in CachedToken.
+func WithTokenSource(src TokenSource) Option {
+	return func(c *Client) error {
+		if src == nil {
+			return errors.New("invalid token source: nil")
+		}
+		c.tokenSource = src
+		return nil
+	}
+}
+
+// StaticToken returns a source that always returns token
+func StaticToken(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		if token == "" {
+			return "", ErrNoToken
+		}
+		return token, nil
+	})
+}
+
+// EnvToken returns a source that reads the environment variable name
+func EnvToken(name string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if token == "" {
+			return "", fmt.Errorf("%w: $%s is not set", ErrNoToken, name)
+		}
+		return token, nil
+	})
+}
+
+// FileToken returns a source that reads the token from a file, ignoring
+// surrounding whitespace. Outside Windows the file must not be readable by
+// other users, as with SSH keys.
+func FileToken(path string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		data, err := readPrivateFile(path)
+		if err != nil {
+			return "", err
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("%w: %s is empty", ErrNoToken, path)
+		}
+		return token, nil
+	})
+}
+
+// CommandToken returns a source that runs a command and uses its trimmed
+// output, for fetching the token from a password manager or keychain:
+//
+//	up.CommandToken("pass", "show", "up/personal")
+//	up.CommandToken("security", "find-generic-password", "-s", "up", "-w")
+func CommandToken(name string, args ...string) TokenSource {
+	return TokenSourceFunc(func(ctx context.Context) (string, error) {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("token: running %s: %w: %s", name, err, msg)
+			}
+			return "", fmt.Errorf("token: running %s: %w", name, err)
+		}
+		token := strings.TrimSpace(string(out))
+		if token == "" {
+			return "", fmt.Errorf("%w: %s printed nothing", ErrNoToken, name)
+		}
+		return token, nil
+	})
+}
+
+// CachedToken returns a source that asks src once and reuses the token.
+// Failures are not cached, so a later call tries again.
+func CachedToken(src TokenSource) TokenSource {
+	var (
+		mu    sync.Mutex
+		token string
+	)
+	return TokenSourceFunc(func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if token != "" {
+			return token, nil
+		}
+		t, err := src.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		token = t
+		return token, nil
+	})
+}
+
+// Encrypted token files are AES-256-GCM sealed with a key derived from a
+// passphrase using PBKDF2-SHA256
+const (
+	tokenFileVersion    = 1
+	tokenFileKDF        = "pbkdf2-sha256"
+	tokenFileIterations = 600_000
+	// tokenFileMaxIterations bounds the work a crafted file can demand
+	tokenFileMaxIterations = 10_000_000
+)
+
+// encryptedToken is the JSON form of an encrypted token file
+type encryptedToken struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileToken returns a source that decrypts a file written with
+// EncryptToken. The passphrase comes from another source, typically
+// EnvToken or CommandToken.
+func EncryptedFileToken(path string, passphrase TokenSource) TokenSource {
+	return TokenSourceFunc(func(ctx context.Context) (string, error) {
+		data, err := readPrivateFile(path)
+		if err != nil {
+			return "", err
+		}
+		pass, err := passphrase.Token(ctx)
+		if err != nil {
+			return "", fmt.Errorf("token: passphrase for %s: %w", path, err)
+		}
+		token, err := DecryptToken(data, pass)
+		if err != nil {
+			return "", fmt.Errorf("%w (%s)", err, path)
+		}
+		return token, nil
+	})
+}
+
+// EncryptToken seals token with passphrase and returns the contents of an
+// encrypted token file
+func EncryptToken(token, passphrase string) ([]byte, error) {
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	if passphrase == "" {
+		return nil, errors.New("token: empty passphrase")
+	}
+
+	f := encryptedToken{
+		Version:    tokenFileVersion,
+		KDF:        tokenFileKDF,
+		Iterations: tokenFileIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := f.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	f.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return nil, err
+	}
+	f.Ciphertext = aead.Seal(nil, f.Nonce, []byte(token), nil)
+
+	data, err := json.MarshalIndent(&f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// DecryptToken opens the contents of an encrypted token file
+func DecryptToken(data []byte, passphrase string) (string, error) {
+	var f encryptedToken
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", fmt.Errorf("token: malformed encrypted token: %w", err)
+	}
+	if f.Version != tokenFileVersion || f.KDF != tokenFileKDF {
+		return "", fmt.Errorf("token: unsupported encrypted token version %d (%s)", f.Version, f.KDF)
+	}
+
+	aead, err := f.aead(passphrase)
+	if err != nil {
+		return "", err
+	}
+	if len(f.Nonce) != aead.NonceSize() {
+		return "", errors.New("token: malformed encrypted token: bad nonce")
+	}
+	token, err := aead.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return "", errors.New("token: wrong passphrase or corrupted encrypted token")
+	}
+	return string(token), nil
+}
+
+func (f *encryptedToken) aead(passphrase string) (cipher.AEAD, error) {
+	if f.Iterations <= 0 || f.Iterations > tokenFileMaxIterations {
+		return nil, fmt.Errorf("token: invalid iteration count %d", f.Iterations)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, f.Salt, f.Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readPrivateFile reads a file holding a secret, refusing files other users
+// can read
+func readPrivateFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("token: %s is accessible by other users, run chmod 600 %s", path, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,184 @@
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncryptDecryptToken(t *testing.T) {
+	data, err := EncryptToken("up:yeah:secret", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "up:yeah:secret") {
+		t.Fatal("encrypted file contains the token")
+	}
+
+	token, err := DecryptToken(data, "correct horse")
+	if err != nil || token != "up:yeah:secret" {
+		t.Fatalf("DecryptToken = %q, %v", token, err)
+	}
+	if _, err := DecryptToken(data, "battery staple"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("wrong passphrase returned %v", err)
+	}
+
+	// Every file gets its own salt and nonce
+	again, err := EncryptToken("up:yeah:secret", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) == string(data) {
+		t.Error("encrypting twice produced the same file")
+	}
+}
+
+func TestEncryptTokenRejectsEmpty(t *testing.T) {
+	if _, err := EncryptToken("", "pass"); !errors.Is(err, ErrNoToken) {
+		t.Errorf("empty token returned %v, want ErrNoToken", err)
+	}
+	if _, err := EncryptToken("token", ""); err == nil {
+		t.Error("empty passphrase was accepted")
+	}
+}
+
+func TestDecryptTokenInvalid(t *testing.T) {
+	data, err := EncryptToken("up:yeah:secret", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// modify returns data with fn applied to its decoded form
+	modify := func(fn func(f *encryptedToken)) []byte {
+		var f encryptedToken
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.Fatal(err)
+		}
+		fn(&f)
+		out, _ := json.Marshal(&f)
+		return out
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"not json", []byte("up:yeah:secret"), "malformed"},
+		{"future version", modify(func(f *encryptedToken) { f.Version = 2 }), "unsupported"},
+		{"unknown kdf", modify(func(f *encryptedToken) { f.KDF = "scrypt" }), "unsupported"},
+		{"zero iterations", modify(func(f *encryptedToken) { f.Iterations = 0 }), "iteration count"},
+		{"huge iterations", modify(func(f *encryptedToken) { f.Iterations = 1 << 40 }), "iteration count"},
+		{"short nonce", modify(func(f *encryptedToken) { f.Nonce = f.Nonce[:4] }), "bad nonce"},
+		{"flipped ciphertext", modify(func(f *encryptedToken) { f.Ciphertext[0] ^= 1 }), "corrupted"},
+		{"changed salt", modify(func(f *encryptedToken) { f.Salt[0] ^= 1 }), "corrupted"},
+	}
+	for _, tt := range tests {
+		start := time.Now()
+		_, err := DecryptToken(tt.data, "pass")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: DecryptToken returned %v, want %q", tt.name, err, tt.want)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("%s: rejecting took %s", tt.name, elapsed)
+		}
+	}
+}
+
+func TestFileTokenPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not checked on Windows")
+	}
+	dir := t.TempDir()
+	tests := []struct {
+		mode os.FileMode
+		ok   bool
+	}{
+		{0o600, true},
+		{0o400, true},
+		{0o640, false},
+		{0o604, false},
+		{0o644, false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, "token-"+tt.mode.String())
+		if err := os.WriteFile(path, []byte("  up:yeah:secret\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, tt.mode); err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := FileToken(path).Token(context.Background())
+		if tt.ok && (err != nil || token != "up:yeah:secret") {
+			t.Errorf("mode %s: Token = %q, %v", tt.mode, token, err)
+		}
+		if !tt.ok && (err == nil || !strings.Contains(err.Error(), "chmod 600")) {
+			t.Errorf("mode %s: Token returned %v, want a permissions error", tt.mode, err)
+		}
+	}
+
+	if _, err := FileToken(filepath.Join(dir, "missing")).Token(context.Background()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file returned %v, want os.ErrNotExist", err)
+	}
+	empty := filepath.Join(dir, "empty")
+	os.WriteFile(empty, []byte("\n"), 0o600)
+	if _, err := FileToken(empty).Token(context.Background()); !errors.Is(err, ErrNoToken) {
+		t.Errorf("empty file returned %v, want ErrNoToken", err)
+	}
+}
+
+func TestEncryptedFileToken(t *testing.T) {
+	dir := t.TempDir()
+	data, err := EncryptToken("up:yeah:secret", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "token.enc")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := EncryptedFileToken(path, StaticToken("pass")).Token(context.Background())
+	if err != nil || token != "up:yeah:secret" {
+		t.Errorf("Token = %q, %v", token, err)
+	}
+	if _, err := EncryptedFileToken(path, StaticToken("")).Token(context.Background()); !errors.Is(err, ErrNoToken) {
+		t.Errorf("missing passphrase returned %v, want ErrNoToken", err)
+	}
+
+	if runtime.GOOS != "windows" {
+		os.Chmod(path, 0o644)
+		if _, err := EncryptedFileToken(path, StaticToken("pass")).Token(context.Background()); err == nil || !strings.Contains(err.Error(), "other users") {
+			t.Errorf("world-readable file returned %v, want a permissions error", err)
+		}
+	}
+}
+
+func TestCachedToken(t *testing.T) {
+	var calls int
+	src := CachedToken(TokenSourceFunc(func(context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("keychain locked")
+		}
+		return "up:yeah:secret", nil
+	}))
+
+	if _, err := src.Token(context.Background()); err == nil {
+		t.Fatal("first call succeeded")
+	}
+	for range 3 {
+		if token, err := src.Token(context.Background()); err != nil || token != "up:yeah:secret" {
+			t.Fatalf("Token = %q, %v", token, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("source called %d times, want 2 as failures are not cached", calls)
+	}
+}
@@ -80,11 +80,11 @@ type CardPurchaseMethod struct {
 
 // TransactionRelationships represents the relationships of a transaction
 type TransactionRelationships struct {
-	Account         AccountRelationship   `json:"account"`
-	TransferAccount *AccountRelationship  `json:"transferAccount"`
-	Category        *CategoryRelationship `json:"category"`
-	ParentCategory  *CategoryRelationship `json:"parentCategory"`
-	Tags            TagRelationships      `json:"tags"`
+	Account         AccountRelationship         `json:"account"`
+	TransferAccount *AccountRelationship        `json:"transferAccount"`
+	Category        *CategoryRelationship       `json:"category"`
+	ParentCategory  *CategoryRelationship       `json:"parentCategory"`
+	Tags            TransactionTagsRelationship `json:"tags"`
 }
 
 type AccountRelationship struct {
@@ -97,16 +97,28 @@ type AccountData struct {
 	ID   string `json:"id"`
 }
 
+// TransactionTagsRelationship represents the tags of a transaction
+type TransactionTagsRelationship struct {
+	Data  []TagInputResource `json:"data"`
+	Links Links              `json:"links,omitempty"`
+}
+
+// TagIDs returns the IDs of the tags on the transaction
+func (r TransactionTagsRelationship) TagIDs() []string {
+	ids := make([]string, len(r.Data))
+	for i, tag := range r.Data {
+		ids[i] = tag.ID
+	}
+	return ids
+}
+
 type CategoryRelationship struct {
 	Data  *CategoryData `json:"data"`
 	Links Links         `json:"links,omitempty"`
 }
 
 // TransactionListResponse represents the response from listing transactions
-type TransactionListResponse struct {
-	Data  []Transaction `json:"data"`
-	Links Links         `json:"links"`
-}
+type TransactionListResponse = ListResponse[Transaction]
 
 // TransactionGetResponse represents the response from getting a single transaction
 type TransactionGetResponse struct {
@@ -125,46 +137,41 @@ type ListTransactionsOptions struct {
 
 // List returns a list of all transactions
 func (s *TransactionsService) List(ctx context.Context, opts *ListTransactionsOptions) (*TransactionListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "transactions.list")
 	u := "transactions"
+	var listOpts *ListOptions
 	if opts != nil {
 		var err error
 		u, err = addOptions(u, opts)
 		if err != nil {
 			return nil, nil, err
 		}
+		listOpts = &opts.ListOptions
 	}
 
-	var transactions TransactionListResponse
-	resp, err := s.client.paginate(ctx, u, &transactions)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return &transactions, resp, nil
+	return paginate[Transaction](ctx, s.client, u, listOpts)
 }
 
 // ListByAccount returns a list of transactions for a specific account
 func (s *TransactionsService) ListByAccount(ctx context.Context, accountID string, opts *ListTransactionsOptions) (*TransactionListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "transactions.list_by_account")
 	u := fmt.Sprintf("accounts/%s/transactions", accountID)
+	var listOpts *ListOptions
 	if opts != nil {
 		var err error
 		u, err = addOptions(u, opts)
 		if err != nil {
 			return nil, nil, err
 		}
+		listOpts = &opts.ListOptions
 	}
 
-	var transactions TransactionListResponse
-	resp, err := s.client.paginate(ctx, u, &transactions)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return &transactions, resp, nil
+	return paginate[Transaction](ctx, s.client, u, listOpts)
 }
 
 // Get returns a specific transaction by ID
 func (s *TransactionsService) Get(ctx context.Context, transactionID string) (*Transaction, *http.Response, error) {
+	ctx = withOperation(ctx, "transactions.get")
 	u := fmt.Sprintf("transactions/%s", transactionID)
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
@@ -19,6 +19,7 @@ type PingResponse struct {
 
 // Ping makes a basic ping request to verify authentication
 func (s *UtilityService) Ping(ctx context.Context) (*PingResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "utility.ping")
 	req, err := s.client.newRequest("GET", "util/ping", nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating request: %v", err)
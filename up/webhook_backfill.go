@@ -0,0 +1,180 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BackfillOptions specifies the optional parameters for backfilling webhook events
+type BackfillOptions struct {
+	// Since limits the backfill to deliveries and transactions created after this time
+	Since time.Time
+	// Store, if set, skips events that were already handled and records
+	// replayed ones. Pass the receiver's store so live and replayed
+	// deliveries are deduplicated together.
+	Store EventStore
+	// CrossCheck lists transactions created since Since and replays a
+	// synthesised event for any transaction that no delivery log mentions.
+	// It requires Since, and starts no earlier than the oldest delivery log
+	// when the API no longer retains logs back to Since, so transactions
+	// handled before then are not replayed.
+	CrossCheck bool
+}
+
+// BackfillResult reports what a backfill replayed
+type BackfillResult struct {
+	// Replayed holds the IDs of events passed to the handler, oldest first
+	Replayed []string
+	// Skipped holds the IDs of events the store had already seen
+	Skipped []string
+	// Synthesized holds the IDs of transactions replayed by the cross-check
+	Synthesized []string
+	// Failed maps event IDs to the error the handler returned, or to the
+	// error decoding their delivery log
+	Failed map[string]error
+}
+
+// Backfill replays webhook events that were never delivered successfully
+// through handle, typically WebhookDispatcher.Dispatch. The original payloads
+// are recovered from the delivery logs. Handler failures and logs that cannot
+// be decoded do not stop the backfill; they are recorded in the result and
+// returned joined together.
+func (s *WebhooksService) Backfill(ctx context.Context, webhookID string, handle WebhookEventFunc, opts *BackfillOptions) (*BackfillResult, error) {
+	if opts == nil {
+		opts = &BackfillOptions{}
+	}
+	if opts.CrossCheck && opts.Since.IsZero() {
+		return nil, errors.New("webhook backfill: CrossCheck requires Since")
+	}
+
+	var (
+		failed    []WebhookDeliveryLog
+		delivered = make(map[string]bool)
+		logged    = make(map[string]bool)
+		// oldest is the creation time of the oldest log read, and complete
+		// reports whether the logs reached back to Since
+		oldest   time.Time
+		complete bool
+	)
+
+	for log, err := range s.AllLogs(ctx, webhookID, nil) {
+		if err != nil {
+			return nil, err
+		}
+		// Logs are returned newest first
+		if log.Attributes.CreatedAt.Before(opts.Since) {
+			complete = true
+			break
+		}
+		oldest = log.Attributes.CreatedAt
+
+		eventID := log.Relationships.WebhookEvent.Data.ID
+		if log.Attributes.DeliveryStatus == WebhookDeliveryStatusDelivered {
+			delivered[eventID] = true
+		} else {
+			failed = append(failed, log)
+		}
+
+		if event, err := ParseWebhookEvent([]byte(log.Attributes.Request.Body)); err == nil && event.TransactionID() != "" {
+			logged[event.TransactionID()] = true
+		}
+	}
+
+	var (
+		result = &BackfillResult{Failed: make(map[string]error)}
+		errs   []error
+		events []*WebhookEvent
+		queued = make(map[string]bool)
+	)
+	for _, log := range slices.Backward(failed) {
+		eventID := log.Relationships.WebhookEvent.Data.ID
+		if delivered[eventID] || queued[eventID] {
+			continue
+		}
+		queued[eventID] = true
+		event, err := ParseWebhookEvent([]byte(log.Attributes.Request.Body))
+		if err != nil {
+			if eventID == "" {
+				eventID = log.ID
+			}
+			result.Failed[eventID] = err
+			errs = append(errs, fmt.Errorf("delivery log %s: %w", log.ID, err))
+			continue
+		}
+		events = append(events, event)
+	}
+
+	if opts.CrossCheck {
+		since := opts.Since
+		if !complete && !oldest.IsZero() {
+			since = oldest
+		}
+		var missing []*WebhookEvent
+		listOpts := &ListTransactionsOptions{Since: &since}
+		for tx, err := range s.client.Transactions.All(ctx, listOpts) {
+			if err != nil {
+				return nil, err
+			}
+			if !logged[tx.ID] {
+				missing = append(missing, synthesizeTransactionEvent(&tx))
+				result.Synthesized = append(result.Synthesized, tx.ID)
+			}
+		}
+		// Transactions are returned newest first
+		slices.Reverse(missing)
+		slices.Reverse(result.Synthesized)
+		events = append(events, missing...)
+	}
+
+	for _, event := range events {
+		if opts.Store != nil {
+			seen, err := opts.Store.MarkSeen(ctx, event.ID)
+			if err != nil {
+				return result, err
+			}
+			if seen {
+				result.Skipped = append(result.Skipped, event.ID)
+				continue
+			}
+		}
+
+		if err := handle(ctx, event); err != nil {
+			if opts.Store != nil {
+				_ = opts.Store.Forget(ctx, event.ID)
+			}
+			result.Failed[event.ID] = err
+			errs = append(errs, fmt.Errorf("event %s: %w", event.ID, err))
+			continue
+		}
+		result.Replayed = append(result.Replayed, event.ID)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// synthesizeTransactionEvent builds the event Up would have sent for tx. Its
+// ID is derived from the transaction so repeated backfills deduplicate.
+func synthesizeTransactionEvent(tx *Transaction) *WebhookEvent {
+	event := &WebhookEvent{
+		Type: "webhook-events",
+		ID:   "backfill-" + tx.ID,
+		Attributes: WebhookEventAttributes{
+			EventType: WebhookEventTransactionCreated,
+			CreatedAt: tx.Attributes.CreatedAt,
+		},
+	}
+	if tx.Attributes.Status == TransactionStatusSettled {
+		event.Attributes.EventType = WebhookEventTransactionSettled
+		if tx.Attributes.SettledAt != nil {
+			event.Attributes.CreatedAt = *tx.Attributes.SettledAt
+		}
+	}
+
+	event.Relationships.Transaction = &WebhookEventRelationship{}
+	event.Relationships.Transaction.Data.Type = "transactions"
+	event.Relationships.Transaction.Data.ID = tx.ID
+	return event
+}
@@ -0,0 +1,142 @@
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// backfillServer serves logs for webhook-1 and transactions, built by
+// transactionItem, filtered by filter[since], which it reports through since
+func backfillServer(logs, transactions []any, since *string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /webhooks/webhook-1/logs", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ListResponse[any]{Data: logs})
+	})
+	mux.HandleFunc("GET /transactions", func(w http.ResponseWriter, r *http.Request) {
+		*since = r.URL.Query().Get("filter[since]")
+		from, _ := time.Parse(time.RFC3339, *since)
+		page := ListResponse[any]{Data: []any{}}
+		for _, tx := range transactions {
+			if !tx.(map[string]any)["attributes"].(map[string]any)["createdAt"].(time.Time).Before(from) {
+				page.Data = append(page.Data, tx)
+			}
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	return mux
+}
+
+func deliveryLog(id, eventID, status, body string, createdAt time.Time) any {
+	return map[string]any{
+		"type": "webhook-delivery-logs",
+		"id":   id,
+		"attributes": map[string]any{
+			"request":        map[string]any{"body": body},
+			"deliveryStatus": status,
+			"createdAt":      createdAt,
+		},
+		"relationships": map[string]any{
+			"webhookEvent": map[string]any{"data": map[string]any{"type": "webhook-events", "id": eventID}},
+		},
+	}
+}
+
+func transactionEventBody(eventID, txID string, createdAt time.Time) string {
+	return fmt.Sprintf(`{"data":{"type":"webhook-events","id":%q,"attributes":{"eventType":"TRANSACTION_CREATED","createdAt":%q},`+
+		`"relationships":{"transaction":{"data":{"type":"transactions","id":%q}}}}}`, eventID, createdAt.Format(time.RFC3339), txID)
+}
+
+func transactionItem(id string, createdAt time.Time) any {
+	return map[string]any{
+		"type":       "transactions",
+		"id":         id,
+		"attributes": map[string]any{"status": "SETTLED", "createdAt": createdAt},
+	}
+}
+
+func TestBackfillCrossCheckRequiresSince(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	_, err := c.Webhooks.Backfill(context.Background(), "webhook-1", func(context.Context, *WebhookEvent) error {
+		return nil
+	}, &BackfillOptions{CrossCheck: true})
+	if err == nil {
+		t.Fatal("Backfill with CrossCheck and no Since did not fail")
+	}
+}
+
+func TestBackfillContinuesPastBadLogs(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	logs := []any{
+		deliveryLog("log-3", "event-3", "BAD_RESPONSE_CODE", transactionEventBody("event-3", "tx-3", now), now),
+		deliveryLog("log-2", "event-2", "UNDELIVERABLE", `{"data":`, now.Add(-time.Minute)),
+		deliveryLog("log-1", "event-1", "BAD_RESPONSE_CODE", transactionEventBody("event-1", "tx-1", now), now.Add(-2*time.Minute)),
+	}
+	var since string
+	c := newTestClient(t, backfillServer(logs, nil, &since))
+
+	var handled []string
+	result, err := c.Webhooks.Backfill(context.Background(), "webhook-1", func(ctx context.Context, e *WebhookEvent) error {
+		handled = append(handled, e.ID)
+		return nil
+	}, nil)
+
+	if err == nil || !strings.Contains(err.Error(), "log-2") {
+		t.Errorf("Backfill returned %v, want the log-2 decoding error", err)
+	}
+	if want := []string{"event-1", "event-3"}; !slices.Equal(handled, want) || !slices.Equal(result.Replayed, want) {
+		t.Errorf("handled %v, replayed %v, want %v", handled, result.Replayed, want)
+	}
+	if result.Failed["event-2"] == nil || len(result.Failed) != 1 {
+		t.Errorf("Failed = %v, want only event-2", result.Failed)
+	}
+}
+
+func TestBackfillCrossCheckWindow(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	logs := []any{
+		deliveryLog("log-2", "event-2", "DELIVERED", transactionEventBody("event-2", "tx-2", now), now.Add(-time.Hour)),
+		deliveryLog("log-1", "event-1", "DELIVERED", transactionEventBody("event-1", "tx-1", now), now.Add(-2*time.Hour)),
+	}
+	transactions := []any{
+		transactionItem("tx-3", now),
+		transactionItem("tx-2", now.Add(-time.Hour)),
+		transactionItem("tx-1", now.Add(-2*time.Hour)),
+	}
+
+	tests := []struct {
+		name      string
+		since     time.Time
+		wantSince time.Time
+	}{
+		// The logs reach back past Since, so every transaction since then is covered
+		{"logs cover since", now.Add(-90 * time.Minute), now.Add(-90 * time.Minute)},
+		// Logs before the oldest one have expired; transactions before it may
+		// have been delivered and must not be replayed
+		{"logs expired", now.Add(-30 * 24 * time.Hour), now.Add(-2 * time.Hour)},
+	}
+	for _, tt := range tests {
+		var since string
+		c := newTestClient(t, backfillServer(logs, transactions, &since))
+
+		result, err := c.Webhooks.Backfill(context.Background(), "webhook-1", func(context.Context, *WebhookEvent) error {
+			return nil
+		}, &BackfillOptions{Since: tt.since, CrossCheck: true})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got, _ := time.Parse(time.RFC3339, since); !got.Equal(tt.wantSince) {
+			t.Errorf("%s: listed transactions since %s, want %s", tt.name, since, tt.wantSince.Format(time.RFC3339))
+		}
+		if !slices.Equal(result.Synthesized, []string{"tx-3"}) {
+			t.Errorf("%s: synthesized %v, want [tx-3]", tt.name, result.Synthesized)
+		}
+	}
+}
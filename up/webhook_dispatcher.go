@@ -0,0 +1,252 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// TransactionEventFunc handles a transaction webhook event. tx is the related
+// transaction when the dispatcher hydrates events, and nil otherwise.
+type TransactionEventFunc func(ctx context.Context, event *WebhookEvent, tx *Transaction) error
+
+// WebhookMiddleware wraps the handling of every dispatched event
+type WebhookMiddleware func(next WebhookEventFunc) WebhookEventFunc
+
+// WebhookDispatcher routes webhook events to handlers registered per event type
+type WebhookDispatcher struct {
+	mu          sync.RWMutex
+	created     []TransactionEventFunc
+	settled     []TransactionEventFunc
+	deleted     []WebhookEventFunc
+	ping        []WebhookEventFunc
+	fallback    []WebhookEventFunc
+	middlewares []WebhookMiddleware
+
+	client     *Client
+	async      bool
+	asyncError func(event *WebhookEvent, err error)
+	asyncStore EventStore
+	wg         sync.WaitGroup
+}
+
+// WebhookDispatcherOption configures a WebhookDispatcher
+type WebhookDispatcherOption func(*WebhookDispatcher)
+
+// WithHydration fetches the related transaction with client before calling
+// created and settled handlers
+func WithHydration(client *Client) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.client = client
+	}
+}
+
+// WithAsync handles events in the background so the webhook is acknowledged
+// immediately. Handler errors and panics are passed to onError, which may be
+// nil. As Up is told the delivery succeeded before the handler runs, pass the
+// handler's event store to WithAsyncEventStore so failed events are not
+// ignored when delivered again.
+func WithAsync(onError func(event *WebhookEvent, err error)) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.async = true
+		d.asyncError = onError
+	}
+}
+
+// WithAsyncEventStore forgets events in store when their asynchronous
+// handling fails, so a redelivery or backfill handles them again. It should
+// be the store given to the WebhookHandler with WithEventStore.
+func WithAsyncEventStore(store EventStore) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.asyncStore = store
+	}
+}
+
+// NewWebhookDispatcher returns an empty dispatcher. Its Dispatch method can be
+// passed to NewWebhookHandler.
+func NewWebhookDispatcher(opts ...WebhookDispatcherOption) *WebhookDispatcher {
+	d := &WebhookDispatcher{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// OnTransactionCreated registers a handler for TRANSACTION_CREATED events
+func (d *WebhookDispatcher) OnTransactionCreated(fn TransactionEventFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.created = append(d.created, fn)
+}
+
+// OnTransactionSettled registers a handler for TRANSACTION_SETTLED events
+func (d *WebhookDispatcher) OnTransactionSettled(fn TransactionEventFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.settled = append(d.settled, fn)
+}
+
+// OnTransactionDeleted registers a handler for TRANSACTION_DELETED events. The
+// transaction no longer exists so it is never hydrated.
+func (d *WebhookDispatcher) OnTransactionDeleted(fn WebhookEventFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deleted = append(d.deleted, fn)
+}
+
+// OnPing registers a handler for PING events
+func (d *WebhookDispatcher) OnPing(fn WebhookEventFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ping = append(d.ping, fn)
+}
+
+// OnDefault registers a handler for events whose type has no other handlers,
+// including event types added to the API after this package
+func (d *WebhookDispatcher) OnDefault(fn WebhookEventFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fallback = append(d.fallback, fn)
+}
+
+// Use adds middlewares around event handling. The first middleware given is the outermost.
+func (d *WebhookDispatcher) Use(mws ...WebhookMiddleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.middlewares = append(d.middlewares, mws...)
+}
+
+// Dispatch passes event to the handlers registered for its type, or to the
+// OnDefault handlers if there are none. Events without any handler are
+// ignored. In async mode it returns immediately.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event *WebhookEvent) error {
+	d.mu.RLock()
+	handle := WebhookEventFunc(d.route)
+	for i := len(d.middlewares) - 1; i >= 0; i-- {
+		handle = d.middlewares[i](handle)
+	}
+	d.mu.RUnlock()
+
+	if !d.async {
+		return handle(ctx, event)
+	}
+
+	// The request context ends once the webhook has been acknowledged
+	ctx = context.WithoutCancel(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		// Nothing above this goroutine can recover a panic, so one is
+		// always turned into an error here
+		err := RecoverPanics()(handle)(ctx, event)
+		if err == nil {
+			return
+		}
+		if d.asyncStore != nil {
+			_ = d.asyncStore.Forget(ctx, event.ID)
+		}
+		if d.asyncError != nil {
+			d.asyncError(event, err)
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until all events being handled asynchronously have finished
+func (d *WebhookDispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// route calls every handler registered for the event's type
+func (d *WebhookDispatcher) route(ctx context.Context, event *WebhookEvent) error {
+	d.mu.RLock()
+	created, settled := d.created, d.settled
+	deleted, ping, fallback := d.deleted, d.ping, d.fallback
+	d.mu.RUnlock()
+
+	switch event.Attributes.EventType {
+	case WebhookEventTransactionCreated:
+		if len(created) > 0 {
+			return d.routeTransaction(ctx, event, created)
+		}
+	case WebhookEventTransactionSettled:
+		if len(settled) > 0 {
+			return d.routeTransaction(ctx, event, settled)
+		}
+	case WebhookEventTransactionDeleted:
+		if len(deleted) > 0 {
+			return callAll(ctx, event, deleted)
+		}
+	case WebhookEventPing:
+		if len(ping) > 0 {
+			return callAll(ctx, event, ping)
+		}
+	}
+	return callAll(ctx, event, fallback)
+}
+
+func (d *WebhookDispatcher) routeTransaction(ctx context.Context, event *WebhookEvent, handlers []TransactionEventFunc) error {
+	var tx *Transaction
+	if d.client != nil && event.TransactionID() != "" {
+		var err error
+		tx, _, err = d.client.Transactions.Get(ctx, event.TransactionID())
+		if err != nil {
+			return fmt.Errorf("webhook: hydrating transaction %s: %w", event.TransactionID(), err)
+		}
+	}
+
+	for _, fn := range handlers {
+		if err := fn(ctx, event, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func callAll(ctx context.Context, event *WebhookEvent, handlers []WebhookEventFunc) error {
+	for _, fn := range handlers {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecoverPanics turns a panic in a handler into an error
+func RecoverPanics() WebhookMiddleware {
+	return func(next WebhookEventFunc) WebhookEventFunc {
+		return func(ctx context.Context, event *WebhookEvent) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("webhook: panic handling event %s: %v\n%s", event.ID, r, debug.Stack())
+				}
+			}()
+			return next(ctx, event)
+		}
+	}
+}
+
+// LogEvents logs every event with its outcome and duration
+func LogEvents(logger *slog.Logger) WebhookMiddleware {
+	return func(next WebhookEventFunc) WebhookEventFunc {
+		return func(ctx context.Context, event *WebhookEvent) error {
+			start := time.Now()
+			err := next(ctx, event)
+			attrs := []any{
+				"id", event.ID,
+				"type", event.Attributes.EventType,
+				"transaction", event.TransactionID(),
+				"took", time.Since(start),
+			}
+			if err != nil {
+				logger.ErrorContext(ctx, "webhook event failed", append(attrs, "error", err)...)
+			} else {
+				logger.InfoContext(ctx, "webhook event handled", attrs...)
+			}
+			return err
+		}
+	}
+}
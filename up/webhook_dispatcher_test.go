@@ -0,0 +1,244 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testEvent builds an event of eventType, related to transactionID if it is not empty
+func testEvent(id string, eventType WebhookEventTypeEnum, transactionID string) *WebhookEvent {
+	event := &WebhookEvent{Type: "webhook-events", ID: id}
+	event.Attributes.EventType = eventType
+	if transactionID != "" {
+		event.Relationships.Transaction = &WebhookEventRelationship{}
+		event.Relationships.Transaction.Data.Type = "transactions"
+		event.Relationships.Transaction.Data.ID = transactionID
+	}
+	return event
+}
+
+// recordingDispatcher registers a handler for every event type, each
+// appending its name to the returned log
+func recordingDispatcher(opts ...WebhookDispatcherOption) (*WebhookDispatcher, *[]string) {
+	var calls []string
+	d := NewWebhookDispatcher(opts...)
+	tx := func(name string) TransactionEventFunc {
+		return func(ctx context.Context, event *WebhookEvent, tx *Transaction) error {
+			if tx != nil {
+				name += ":" + tx.ID
+			}
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	plain := func(name string) WebhookEventFunc {
+		return func(ctx context.Context, event *WebhookEvent) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	d.OnTransactionCreated(tx("created"))
+	d.OnTransactionSettled(tx("settled"))
+	d.OnTransactionDeleted(plain("deleted"))
+	d.OnPing(plain("ping"))
+	return d, &calls
+}
+
+func TestDispatcherRoutes(t *testing.T) {
+	tests := []struct {
+		eventType WebhookEventTypeEnum
+		want      string
+	}{
+		{WebhookEventTransactionCreated, "created"},
+		{WebhookEventTransactionSettled, "settled"},
+		{WebhookEventTransactionDeleted, "deleted"},
+		{WebhookEventPing, "ping"},
+		{"TRANSACTION_SHREDDED", ""},
+	}
+	for _, tt := range tests {
+		d, calls := recordingDispatcher()
+		if err := d.Dispatch(context.Background(), testEvent("event-1", tt.eventType, "tx-1")); err != nil {
+			t.Errorf("%s: %v", tt.eventType, err)
+		}
+		if got := strings.Join(*calls, ","); got != tt.want {
+			t.Errorf("%s: called %q, want %q", tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestDispatcherDefault(t *testing.T) {
+	d := NewWebhookDispatcher()
+	var pinged bool
+	d.OnPing(func(ctx context.Context, event *WebhookEvent) error {
+		pinged = true
+		return nil
+	})
+	var fallback []WebhookEventTypeEnum
+	d.OnDefault(func(ctx context.Context, event *WebhookEvent) error {
+		fallback = append(fallback, event.Attributes.EventType)
+		return nil
+	})
+
+	for _, eventType := range []WebhookEventTypeEnum{WebhookEventPing, WebhookEventTransactionSettled, "TRANSACTION_SHREDDED"} {
+		if err := d.Dispatch(context.Background(), testEvent("event-1", eventType, "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !pinged {
+		t.Error("ping handler was not called")
+	}
+	if len(fallback) != 2 || fallback[0] != WebhookEventTransactionSettled || fallback[1] != "TRANSACTION_SHREDDED" {
+		t.Errorf("default handler saw %v, want the settled and unknown events", fallback)
+	}
+}
+
+func TestDispatcherHydrates(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/tx-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data":{"type":"transactions","id":"tx-1"}}`))
+	}))
+	d, calls := recordingDispatcher(WithHydration(c))
+
+	if err := d.Dispatch(context.Background(), testEvent("event-1", WebhookEventTransactionSettled, "tx-1")); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(*calls, ","); got != "settled:tx-1" {
+		t.Errorf("called %q, want the settled handler with tx-1", got)
+	}
+
+	err := d.Dispatch(context.Background(), testEvent("event-2", WebhookEventTransactionCreated, "tx-2"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Dispatch with a missing transaction returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestDispatcherStopsAtFirstError(t *testing.T) {
+	d := NewWebhookDispatcher()
+	boom := errors.New("boom")
+	var second bool
+	d.OnTransactionDeleted(func(ctx context.Context, event *WebhookEvent) error { return boom })
+	d.OnTransactionDeleted(func(ctx context.Context, event *WebhookEvent) error {
+		second = true
+		return nil
+	})
+
+	if err := d.Dispatch(context.Background(), testEvent("event-1", WebhookEventTransactionDeleted, "tx-1")); !errors.Is(err, boom) {
+		t.Errorf("Dispatch returned %v, want boom", err)
+	}
+	if second {
+		t.Error("second handler ran after the first failed")
+	}
+}
+
+func TestDispatcherMiddlewareOrder(t *testing.T) {
+	d := NewWebhookDispatcher()
+	var order []string
+	mw := func(name string) WebhookMiddleware {
+		return func(next WebhookEventFunc) WebhookEventFunc {
+			return func(ctx context.Context, event *WebhookEvent) error {
+				order = append(order, name)
+				return next(ctx, event)
+			}
+		}
+	}
+	d.Use(mw("outer"), mw("inner"))
+	d.OnPing(func(ctx context.Context, event *WebhookEvent) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	if err := d.Dispatch(context.Background(), testEvent("event-1", WebhookEventPing, "")); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, ","); got != "outer,inner,handler" {
+		t.Errorf("order = %s", got)
+	}
+}
+
+func TestDispatcherRecoverPanics(t *testing.T) {
+	d := NewWebhookDispatcher()
+	d.Use(RecoverPanics())
+	d.OnPing(func(ctx context.Context, event *WebhookEvent) error { panic("kaboom") })
+
+	err := d.Dispatch(context.Background(), testEvent("event-1", WebhookEventPing, ""))
+	if err == nil || !strings.Contains(err.Error(), "kaboom") || !strings.Contains(err.Error(), "event-1") {
+		t.Errorf("Dispatch returned %v, want the recovered panic", err)
+	}
+}
+
+func TestDispatcherAsyncFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler WebhookEventFunc
+		want    string
+	}{
+		{"error", func(ctx context.Context, event *WebhookEvent) error { return errors.New("ledger offline") }, "ledger offline"},
+		// RecoverPanics is not used, the goroutine must recover by itself
+		{"panic", func(ctx context.Context, event *WebhookEvent) error { panic("kaboom") }, "kaboom"},
+	}
+	for _, tt := range tests {
+		store := NewMemoryEventStore(10, 0)
+		var (
+			mu     sync.Mutex
+			failed []error
+		)
+		d := NewWebhookDispatcher(WithAsync(func(event *WebhookEvent, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, err)
+		}), WithAsyncEventStore(store))
+		d.OnPing(tt.handler)
+
+		// The handler marks the event seen before dispatching it
+		ctx, cancel := context.WithCancel(context.Background())
+		store.MarkSeen(ctx, "event-1")
+		if err := d.Dispatch(ctx, testEvent("event-1", WebhookEventPing, "")); err != nil {
+			t.Fatalf("%s: async Dispatch returned %v", tt.name, err)
+		}
+		cancel()
+		d.Wait()
+
+		mu.Lock()
+		if len(failed) != 1 || !strings.Contains(failed[0].Error(), tt.want) {
+			t.Errorf("%s: onError saw %v, want %q", tt.name, failed, tt.want)
+		}
+		mu.Unlock()
+		if seen, _ := store.MarkSeen(context.Background(), "event-1"); seen {
+			t.Errorf("%s: failed event is still marked seen", tt.name)
+		}
+	}
+}
+
+func TestDispatcherAsyncSuccess(t *testing.T) {
+	store := NewMemoryEventStore(10, 0)
+	d := NewWebhookDispatcher(WithAsync(func(event *WebhookEvent, err error) {
+		t.Errorf("onError called with %v", err)
+	}), WithAsyncEventStore(store))
+
+	var handled bool
+	d.OnTransactionDeleted(func(ctx context.Context, event *WebhookEvent) error {
+		// The request context may have ended already
+		handled = ctx.Err() == nil
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	store.MarkSeen(ctx, "event-1")
+	d.Dispatch(ctx, testEvent("event-1", WebhookEventTransactionDeleted, "tx-1"))
+	cancel()
+	d.Wait()
+
+	if !handled {
+		t.Error("handler did not run with a live context")
+	}
+	if seen, _ := store.MarkSeen(context.Background(), "event-1"); !seen {
+		t.Error("handled event was forgotten")
+	}
+}
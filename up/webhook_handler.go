@@ -0,0 +1,209 @@
+package up
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 signature of a webhook event body
+	SignatureHeader = "X-Up-Authenticity-Signature"
+
+	defaultMaxWebhookBodyBytes = 1 << 20
+	// defaultEventTTL is how long the default store remembers events, and
+	// the default maximum event age
+	defaultEventTTL = 24 * time.Hour
+)
+
+// WebhookEventFunc handles a verified webhook event. Returning an error
+// responds with a 500 so that Up retries the delivery.
+type WebhookEventFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler is an http.Handler that receives webhook events from Up,
+// verifies their signature and passes them to a callback
+type WebhookHandler struct {
+	secretKey    []byte
+	handle       WebhookEventFunc
+	maxBodyBytes int64
+	errorLog     func(r *http.Request, err error)
+	store        EventStore
+	maxEventAge  time.Duration
+	onDuplicate  func(ctx context.Context, event *WebhookEvent)
+
+	mu sync.Mutex
+	// inFlight holds the IDs of events being handled
+	inFlight map[string]bool
+}
+
+// WebhookHandlerOption configures a WebhookHandler
+type WebhookHandlerOption func(*WebhookHandler)
+
+// WithMaxBodyBytes rejects payloads larger than n bytes. The default is 1 MiB.
+func WithMaxBodyBytes(n int64) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// WithErrorLog is called with every rejected or failed delivery
+func WithErrorLog(fn func(r *http.Request, err error)) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.errorLog = fn
+	}
+}
+
+// NewWebhookHandler returns a handler that verifies events with secretKey,
+// as returned in WebhookAttributes.SecretKey when the webhook was created
+func NewWebhookHandler(secretKey string, fn WebhookEventFunc, opts ...WebhookHandlerOption) *WebhookHandler {
+	h := &WebhookHandler{
+		secretKey:    []byte(secretKey),
+		handle:       fn,
+		maxBodyBytes: defaultMaxWebhookBodyBytes,
+		store:        NewMemoryEventStore(10000, defaultEventTTL),
+		maxEventAge:  defaultEventTTL,
+		inFlight:     make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	// An event older than a memory store's TTL may have been forgotten, so
+	// accepting it could handle it twice
+	if ms, ok := h.store.(*MemoryEventStore); ok && ms.ttl > 0 && (h.maxEventAge <= 0 || h.maxEventAge > ms.ttl) {
+		h.maxEventAge = ms.ttl
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, http.StatusMethodNotAllowed, errors.New("webhook: method not allowed"))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.fail(w, r, http.StatusRequestEntityTooLarge, errors.New("webhook: payload too large"))
+			return
+		}
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !VerifyWebhookSignature(h.secretKey, body, r.Header.Get(SignatureHeader)) {
+		h.fail(w, r, http.StatusUnauthorized, errors.New("webhook: invalid signature"))
+		return
+	}
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if h.maxEventAge > 0 && time.Since(event.Attributes.CreatedAt) > h.maxEventAge {
+		h.fail(w, r, http.StatusBadRequest, fmt.Errorf("webhook: event %s is older than %s", event.ID, h.maxEventAge))
+		return
+	}
+
+	if h.store != nil {
+		// A duplicate of an event still being handled is refused until the
+		// first delivery finishes, as that may still fail and be forgotten
+		if !h.begin(event.ID) {
+			w.Header().Set("Retry-After", "1")
+			h.fail(w, r, http.StatusConflict, fmt.Errorf("webhook: event %s is already being handled", event.ID))
+			return
+		}
+		defer h.end(event.ID)
+
+		seen, err := h.store.MarkSeen(r.Context(), event.ID)
+		if err != nil {
+			h.fail(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if seen {
+			// Acknowledge duplicates so Up stops redelivering them
+			if h.onDuplicate != nil {
+				h.onDuplicate(r.Context(), event)
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if err := h.handle(r.Context(), event); err != nil {
+		if h.store != nil {
+			// Let Up's retry of this event be handled again
+			_ = h.store.Forget(context.WithoutCancel(r.Context()), event.ID)
+		}
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// begin marks id in flight and reports whether it was not already
+func (h *WebhookHandler) begin(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.inFlight[id] {
+		return false
+	}
+	h.inFlight[id] = true
+	return true
+}
+
+func (h *WebhookHandler) end(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.inFlight, id)
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.errorLog != nil {
+		h.errorLog(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// VerifyWebhookSignature reports whether signature is the hex encoded
+// HMAC-SHA256 of body keyed with secretKey. The comparison is constant time.
+func VerifyWebhookSignature(secretKey, body []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	return hmac.Equal(got, SignWebhookBody(secretKey, body))
+}
+
+// SignWebhookBody returns the raw HMAC-SHA256 of body keyed with secretKey
+func SignWebhookBody(secretKey, body []byte) []byte {
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// ParseWebhookEvent decodes a webhook event payload
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var payload WebhookEventResponse
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("webhook: malformed payload: %w", err)
+	}
+	if payload.Data.ID == "" || payload.Data.Attributes.EventType == "" {
+		return nil, errors.New("webhook: payload is missing the event id or type")
+	}
+	return &payload.Data, nil
+}
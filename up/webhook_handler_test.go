@@ -0,0 +1,134 @@
+package up
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "webhook-secret"
+
+// eventBody returns a webhook event payload created at createdAt
+func eventBody(id string, eventType WebhookEventTypeEnum, createdAt time.Time) string {
+	return fmt.Sprintf(`{"data":{"type":"webhook-events","id":%q,"attributes":{"eventType":%q,"createdAt":%q}}}`,
+		id, eventType, createdAt.Format(time.RFC3339))
+}
+
+// deliver sends body to h signed with secret and returns the response
+func deliver(h http.Handler, secret, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, hex.EncodeToString(SignWebhookBody([]byte(secret), []byte(body))))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWebhookHandlerValidSignature(t *testing.T) {
+	var got *WebhookEvent
+	h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+		got = event
+		return nil
+	})
+
+	rec := deliver(h, testSecret, eventBody("event-1", WebhookEventTransactionCreated, time.Now()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got == nil || got.ID != "event-1" || got.Attributes.EventType != WebhookEventTransactionCreated {
+		t.Errorf("handler got %+v", got)
+	}
+}
+
+func TestWebhookHandlerRejects(t *testing.T) {
+	body := eventBody("event-1", WebhookEventPing, time.Now())
+	sign := func(b string) string {
+		return hex.EncodeToString(SignWebhookBody([]byte(testSecret), []byte(b)))
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		signature string
+		want      int
+	}{
+		{"wrong secret", http.MethodPost, body, hex.EncodeToString(SignWebhookBody([]byte("other"), []byte(body))), http.StatusUnauthorized},
+		{"missing signature", http.MethodPost, body, "", http.StatusUnauthorized},
+		{"non-hex signature", http.MethodPost, body, "not-hex!", http.StatusUnauthorized},
+		{"signature of another body", http.MethodPost, body, sign(body + " "), http.StatusUnauthorized},
+		{"oversized body", http.MethodPost, strings.Repeat("x", 2048), sign(strings.Repeat("x", 2048)), http.StatusRequestEntityTooLarge},
+		{"malformed JSON", http.MethodPost, `{"data":`, sign(`{"data":`), http.StatusBadRequest},
+		{"missing id", http.MethodPost, `{"data":{"attributes":{"eventType":"PING"}}}`, sign(`{"data":{"attributes":{"eventType":"PING"}}}`), http.StatusBadRequest},
+		{"missing type", http.MethodPost, `{"data":{"id":"event-1"}}`, sign(`{"data":{"id":"event-1"}}`), http.StatusBadRequest},
+		{"GET", http.MethodGet, "", "", http.StatusMethodNotAllowed},
+		{"PUT", http.MethodPut, body, sign(body), http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		var (
+			called bool
+			logged error
+		)
+		h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+			called = true
+			return nil
+		}, WithMaxBodyBytes(1024), WithErrorLog(func(r *http.Request, err error) { logged = err }))
+
+		req := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+		if tt.signature != "" {
+			req.Header.Set(SignatureHeader, tt.signature)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+		if called {
+			t.Errorf("%s: callback was called", tt.name)
+		}
+		if logged == nil {
+			t.Errorf("%s: error log was not called", tt.name)
+		}
+		if tt.want == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != http.MethodPost {
+			t.Errorf("%s: Allow = %q, want POST", tt.name, rec.Header().Get("Allow"))
+		}
+	}
+}
+
+func TestWebhookHandlerCallbackError(t *testing.T) {
+	var logged error
+	h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+		return errors.New("ledger unavailable")
+	}, WithErrorLog(func(r *http.Request, err error) { logged = err }))
+
+	rec := deliver(h, testSecret, eventBody("event-1", WebhookEventTransactionSettled, time.Now()))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	if logged == nil || logged.Error() != "ledger unavailable" {
+		t.Errorf("error log got %v", logged)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"data":{}}`)
+	sig := hex.EncodeToString(SignWebhookBody([]byte(testSecret), body))
+
+	if !VerifyWebhookSignature([]byte(testSecret), body, sig) {
+		t.Error("valid signature rejected")
+	}
+	if !VerifyWebhookSignature([]byte(testSecret), body, strings.ToUpper(sig)) {
+		t.Error("upper case hex signature rejected")
+	}
+	for _, bad := range []string{"", "zz", sig[:10], sig + "00"} {
+		if VerifyWebhookSignature([]byte(testSecret), body, bad) {
+			t.Errorf("signature %q accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package up
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// WebhookHealth summarises the delivery logs of a webhook over a window
+type WebhookHealth struct {
+	WebhookID string
+	Since     time.Time
+
+	Total           int
+	Delivered       int
+	Undeliverable   int
+	BadResponseCode int
+	// SuccessRate is Delivered / Total, or 1 when there were no deliveries
+	SuccessRate float64
+
+	// LastDelivered is the time of the most recent successful delivery in the window
+	LastDelivered *time.Time
+	// LastFailed is the time of the most recent failed delivery in the window
+	LastFailed *time.Time
+	// FailingStatusCodes counts the status codes of BAD_RESPONSE_CODE
+	// deliveries, most common first
+	FailingStatusCodes []StatusCodeCount
+	// UndeliveredEventIDs lists events with no DELIVERED log in the window,
+	// oldest first, so they can be backfilled
+	UndeliveredEventIDs []string
+}
+
+// StatusCodeCount is the number of deliveries that received a status code
+type StatusCodeCount struct {
+	StatusCode int
+	Count      int
+}
+
+// Health summarises the delivery logs of a webhook created since the given time
+func (s *WebhooksService) Health(ctx context.Context, webhookID string, since time.Time) (*WebhookHealth, error) {
+	var logs []WebhookDeliveryLog
+	for log, err := range s.AllLogs(ctx, webhookID, nil) {
+		if err != nil {
+			return nil, err
+		}
+		// Logs are returned newest first
+		if log.Attributes.CreatedAt.Before(since) {
+			break
+		}
+		logs = append(logs, log)
+	}
+
+	health := SummarizeDeliveryLogs(logs, since)
+	health.WebhookID = webhookID
+	return health, nil
+}
+
+// HealthAll summarises the delivery logs of every webhook since the given time
+func (s *WebhooksService) HealthAll(ctx context.Context, since time.Time) ([]*WebhookHealth, error) {
+	var reports []*WebhookHealth
+	for w, err := range s.All(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		health, err := s.Health(ctx, w.ID, since)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: %w", w.ID, err)
+		}
+		reports = append(reports, health)
+	}
+	return reports, nil
+}
+
+// SummarizeDeliveryLogs builds a health report from delivery logs, ignoring
+// those created before since
+func SummarizeDeliveryLogs(logs []WebhookDeliveryLog, since time.Time) *WebhookHealth {
+	health := &WebhookHealth{Since: since, SuccessRate: 1}
+
+	codes := make(map[int]int)
+	delivered := make(map[string]bool)
+	firstSeen := make(map[string]time.Time)
+
+	for _, log := range logs {
+		attrs := log.Attributes
+		if attrs.CreatedAt.Before(since) {
+			continue
+		}
+		health.Total++
+
+		eventID := log.Relationships.WebhookEvent.Data.ID
+		if t, ok := firstSeen[eventID]; !ok || attrs.CreatedAt.Before(t) {
+			firstSeen[eventID] = attrs.CreatedAt
+		}
+
+		switch attrs.DeliveryStatus {
+		case WebhookDeliveryStatusDelivered:
+			health.Delivered++
+			delivered[eventID] = true
+			health.LastDelivered = latest(health.LastDelivered, attrs.CreatedAt)
+		case WebhookDeliveryStatusUndeliverable:
+			health.Undeliverable++
+			health.LastFailed = latest(health.LastFailed, attrs.CreatedAt)
+		case WebhookDeliveryStatusBadResponseCode:
+			health.BadResponseCode++
+			health.LastFailed = latest(health.LastFailed, attrs.CreatedAt)
+			if attrs.Response != nil {
+				codes[attrs.Response.StatusCode]++
+			}
+		}
+	}
+
+	if health.Total > 0 {
+		health.SuccessRate = float64(health.Delivered) / float64(health.Total)
+	}
+
+	for code, count := range codes {
+		health.FailingStatusCodes = append(health.FailingStatusCodes, StatusCodeCount{StatusCode: code, Count: count})
+	}
+	slices.SortFunc(health.FailingStatusCodes, func(a, b StatusCodeCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.StatusCode, b.StatusCode))
+	})
+
+	for eventID := range firstSeen {
+		if !delivered[eventID] && eventID != "" {
+			health.UndeliveredEventIDs = append(health.UndeliveredEventIDs, eventID)
+		}
+	}
+	slices.SortFunc(health.UndeliveredEventIDs, func(a, b string) int {
+		return cmp.Or(firstSeen[a].Compare(firstSeen[b]), cmp.Compare(a, b))
+	})
+
+	return health
+}
+
+// latest returns the later of *t and u, or u if t is nil
+func latest(t *time.Time, u time.Time) *time.Time {
+	if t != nil && !u.After(*t) {
+		return t
+	}
+	return &u
+}
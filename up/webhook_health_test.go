@@ -0,0 +1,124 @@
+package up
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var healthBase = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// testLog builds a delivery log for eventID, created minutes after healthBase.
+// code is the response status, zero when the delivery got no response.
+func testLog(eventID string, status WebhookDeliveryStatusEnum, code, minutes int) WebhookDeliveryLog {
+	var log WebhookDeliveryLog
+	log.Type = "webhook-delivery-logs"
+	log.ID = "log-" + eventID
+	log.Attributes.DeliveryStatus = status
+	log.Attributes.CreatedAt = healthBase.Add(time.Duration(minutes) * time.Minute)
+	if code != 0 {
+		log.Attributes.Response = &struct {
+			StatusCode int    `json:"statusCode"`
+			Body       string `json:"body"`
+		}{StatusCode: code}
+	}
+	log.Relationships.WebhookEvent.Data.Type = "webhook-events"
+	log.Relationships.WebhookEvent.Data.ID = eventID
+	return log
+}
+
+// healthLogs are newest first, as the API returns them
+var healthLogs = []WebhookDeliveryLog{
+	testLog("e5", WebhookDeliveryStatusDelivered, 200, 50),
+	testLog("e4", WebhookDeliveryStatusBadResponseCode, 503, 40),
+	testLog("e3", WebhookDeliveryStatusDelivered, 200, 30),
+	testLog("e2", WebhookDeliveryStatusBadResponseCode, 500, 25),
+	testLog("e2", WebhookDeliveryStatusBadResponseCode, 503, 20),
+	testLog("e1", WebhookDeliveryStatusUndeliverable, 0, 10),
+	testLog("e0", WebhookDeliveryStatusBadResponseCode, 404, 0),
+}
+
+func TestSummarizeDeliveryLogs(t *testing.T) {
+	h := SummarizeDeliveryLogs(healthLogs, healthBase.Add(5*time.Minute))
+
+	// e0 is before the window
+	if h.Total != 6 || h.Delivered != 2 || h.Undeliverable != 1 || h.BadResponseCode != 3 {
+		t.Errorf("counts = %d total, %d delivered, %d undeliverable, %d bad, want 6, 2, 1, 3",
+			h.Total, h.Delivered, h.Undeliverable, h.BadResponseCode)
+	}
+	if want := 2.0 / 6; h.SuccessRate != want {
+		t.Errorf("success rate = %v, want %v", h.SuccessRate, want)
+	}
+	if want := healthBase.Add(50 * time.Minute); h.LastDelivered == nil || !h.LastDelivered.Equal(want) {
+		t.Errorf("last delivered = %v, want %s", h.LastDelivered, want)
+	}
+	if want := healthBase.Add(40 * time.Minute); h.LastFailed == nil || !h.LastFailed.Equal(want) {
+		t.Errorf("last failed = %v, want %s", h.LastFailed, want)
+	}
+	if want := []StatusCodeCount{{503, 2}, {500, 1}}; !slices.Equal(h.FailingStatusCodes, want) {
+		t.Errorf("failing status codes = %v, want %v", h.FailingStatusCodes, want)
+	}
+	if got := strings.Join(h.UndeliveredEventIDs, ","); got != "e1,e2,e4" {
+		t.Errorf("undelivered events = %s, want e1,e2,e4 oldest first", got)
+	}
+}
+
+func TestSummarizeDeliveryLogsWindow(t *testing.T) {
+	// Only the last two logs are in the window
+	h := SummarizeDeliveryLogs(healthLogs, healthBase.Add(40*time.Minute))
+	if h.Total != 2 || h.SuccessRate != 0.5 {
+		t.Errorf("got %d logs with success rate %v, want 2 and 0.5", h.Total, h.SuccessRate)
+	}
+	if got := strings.Join(h.UndeliveredEventIDs, ","); got != "e4" {
+		t.Errorf("undelivered events = %s, want e4", got)
+	}
+
+	// A failure followed by a successful retry is not undelivered
+	retried := []WebhookDeliveryLog{
+		testLog("e1", WebhookDeliveryStatusDelivered, 200, 20),
+		testLog("e1", WebhookDeliveryStatusBadResponseCode, 500, 10),
+	}
+	h = SummarizeDeliveryLogs(retried, healthBase)
+	if len(h.UndeliveredEventIDs) != 0 || h.SuccessRate != 0.5 {
+		t.Errorf("retried event: undelivered %v, success rate %v", h.UndeliveredEventIDs, h.SuccessRate)
+	}
+}
+
+func TestSummarizeDeliveryLogsEmpty(t *testing.T) {
+	for name, logs := range map[string][]WebhookDeliveryLog{
+		"no logs":          nil,
+		"all before since": healthLogs,
+	} {
+		h := SummarizeDeliveryLogs(logs, healthBase.Add(time.Hour))
+		if h.Total != 0 || h.SuccessRate != 1 {
+			t.Errorf("%s: total %d, success rate %v, want 0 and 1", name, h.Total, h.SuccessRate)
+		}
+		if h.LastDelivered != nil || h.LastFailed != nil || len(h.FailingStatusCodes) != 0 || len(h.UndeliveredEventIDs) != 0 {
+			t.Errorf("%s: report = %+v, want nothing recorded", name, h)
+		}
+	}
+}
+
+func TestWebhookHealthStopsAtSince(t *testing.T) {
+	var pages int
+	logs := pagedHandler(len(healthLogs), func(i int) any { return healthLogs[i] })
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		logs.ServeHTTP(w, r)
+	}))
+
+	h, err := c.Webhooks.Health(context.Background(), "webhook-1", healthBase.Add(35*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.WebhookID != "webhook-1" || h.Total != 2 || h.Delivered != 1 {
+		t.Errorf("report = %+v, want e5 and e4", h)
+	}
+	// The second page holds a log before since, so the third is never requested
+	if pages != 2 {
+		t.Errorf("fetched %d pages, want 2", pages)
+	}
+}
@@ -0,0 +1,128 @@
+package up
+
+import (
+	"context"
+	"fmt"
+)
+
+// WebhookSpec describes a webhook that should exist
+type WebhookSpec struct {
+	URL         string
+	Description string
+}
+
+// ReconcileOptions specifies the optional parameters for reconciling webhooks
+type ReconcileOptions struct {
+	// DryRun computes the plan without creating or deleting anything
+	DryRun bool
+	// Managed limits reconciliation to the webhooks it returns true for, so
+	// webhooks registered by other tools are neither kept nor deleted. When
+	// nil every webhook on the account is managed.
+	Managed func(w *Webhook) bool
+}
+
+// WebhookPlan lists the changes needed to match the desired webhooks
+type WebhookPlan struct {
+	// Keep holds existing webhooks that already match a spec
+	Keep []Webhook
+	// Delete holds existing webhooks that match no spec, or duplicate one
+	Delete []Webhook
+	// Create holds specs without a matching webhook
+	Create []WebhookSpec
+	// Created holds the webhooks created when the plan was applied. Their
+	// secret keys are only returned by the API at creation time.
+	Created []Webhook
+}
+
+// SecretKeys returns the secret keys of created webhooks by URL
+func (p *WebhookPlan) SecretKeys() map[string]string {
+	keys := make(map[string]string, len(p.Created))
+	for _, w := range p.Created {
+		keys[w.Attributes.URL] = w.Attributes.SecretKey
+	}
+	return keys
+}
+
+// IsEmpty reports whether the plan makes no changes
+func (p *WebhookPlan) IsEmpty() bool {
+	return len(p.Delete) == 0 && len(p.Create) == 0
+}
+
+// Reconcile makes the registered webhooks match desired. Webhooks are
+// matched by URL and description; as webhooks cannot be modified, one with
+// a changed description is deleted and recreated. Stale webhooks are deleted
+// before new ones are created to stay within the API's webhook limit. If an
+// error occurs part way through, the plan applied so far is returned with it.
+func (s *WebhooksService) Reconcile(ctx context.Context, desired []WebhookSpec, opts *ReconcileOptions) (*WebhookPlan, error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+
+	existing, _, err := s.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planWebhooks(existing.Data, desired, opts.Managed)
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	for _, w := range plan.Delete {
+		if _, err := s.Delete(ctx, w.ID); err != nil {
+			return plan, fmt.Errorf("deleting webhook %s: %w", w.ID, err)
+		}
+	}
+
+	for _, spec := range plan.Create {
+		var description *string
+		if spec.Description != "" {
+			description = &spec.Description
+		}
+		w, _, err := s.Create(ctx, spec.URL, description)
+		if err != nil {
+			return plan, fmt.Errorf("creating webhook for %s: %w", spec.URL, err)
+		}
+		plan.Created = append(plan.Created, *w)
+	}
+
+	return plan, nil
+}
+
+// planWebhooks diffs existing webhooks against the desired specs
+func planWebhooks(existing []Webhook, desired []WebhookSpec, managed func(w *Webhook) bool) *WebhookPlan {
+	plan := &WebhookPlan{}
+
+	wanted := make(map[WebhookSpec]bool, len(desired))
+	for _, spec := range desired {
+		wanted[spec] = true
+	}
+
+	found := make(map[WebhookSpec]bool, len(desired))
+	for _, w := range existing {
+		if managed != nil && !managed(&w) {
+			continue
+		}
+
+		spec := WebhookSpec{URL: w.Attributes.URL}
+		if w.Attributes.Description != nil {
+			spec.Description = *w.Attributes.Description
+		}
+
+		if wanted[spec] && !found[spec] {
+			found[spec] = true
+			plan.Keep = append(plan.Keep, w)
+		} else {
+			plan.Delete = append(plan.Delete, w)
+		}
+	}
+
+	for _, spec := range desired {
+		if !found[spec] {
+			found[spec] = true
+			plan.Create = append(plan.Create, spec)
+		}
+	}
+
+	return plan
+}
@@ -0,0 +1,55 @@
+package up_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jordanst3wart/up-client/up"
+	"github.com/jordanst3wart/up-client/uptest"
+)
+
+func TestReconcileAgainstFake(t *testing.T) {
+	ctx := context.Background()
+	srv := uptest.NewServer(nil)
+	defer srv.Close()
+	client := srv.UpClient()
+
+	stale := "stale"
+	old, _, err := client.Webhooks.Create(ctx, "https://old.example/hook", &stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desired := []up.WebhookSpec{{URL: "https://new.example/hook", Description: "ledger"}}
+
+	// A dry run only plans
+	plan, err := client.Webhooks.Reconcile(ctx, desired, &up.ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].ID != old.ID || len(plan.Create) != 1 || len(plan.Created) != 0 {
+		t.Errorf("dry run plan = %+v", plan)
+	}
+	list, _, err := client.Webhooks.List(ctx, nil)
+	if err != nil || len(list.Data) != 1 || list.Data[0].ID != old.ID {
+		t.Fatalf("webhooks after a dry run = %+v, %v, want only %s", list, err, old.ID)
+	}
+
+	// Applying it replaces the stale webhook and returns the new secret
+	plan, err = client.Webhooks.Reconcile(ctx, desired, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Created) != 1 || plan.SecretKeys()["https://new.example/hook"] == "" {
+		t.Errorf("applied plan = %+v, want the created webhook and its secret", plan)
+	}
+	list, _, err = client.Webhooks.List(ctx, nil)
+	if err != nil || len(list.Data) != 1 || list.Data[0].Attributes.URL != "https://new.example/hook" {
+		t.Fatalf("webhooks after applying = %+v, %v", list, err)
+	}
+
+	// Nothing is left to do
+	plan, err = client.Webhooks.Reconcile(ctx, desired, nil)
+	if err != nil || !plan.IsEmpty() || len(plan.Keep) != 1 {
+		t.Errorf("second reconcile = %+v, %v, want an empty plan", plan, err)
+	}
+}
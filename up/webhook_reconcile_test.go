@@ -0,0 +1,132 @@
+package up
+
+import (
+	"strings"
+	"testing"
+)
+
+func testWebhook(id, url, description string) Webhook {
+	w := Webhook{Type: "webhooks", ID: id}
+	w.Attributes.URL = url
+	if description != "" {
+		w.Attributes.Description = &description
+	}
+	return w
+}
+
+func webhookIDs(ws []Webhook) string {
+	ids := make([]string, len(ws))
+	for i, w := range ws {
+		ids[i] = w.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func specURLs(specs []WebhookSpec) string {
+	urls := make([]string, len(specs))
+	for i, s := range specs {
+		urls[i] = s.URL + "#" + s.Description
+	}
+	return strings.Join(urls, ",")
+}
+
+func TestPlanWebhooks(t *testing.T) {
+	const a, b = "https://a.example/hook", "https://b.example/hook"
+	tests := []struct {
+		name     string
+		existing []Webhook
+		desired  []WebhookSpec
+		managed  func(w *Webhook) bool
+		// Keep and Delete are webhook IDs, Create is URL#description
+		keep, del, create string
+	}{
+		{
+			name:    "create into an empty account",
+			desired: []WebhookSpec{{URL: a, Description: "ledger"}, {URL: b}},
+			create:  a + "#ledger," + b + "#",
+		},
+		{
+			name:     "keep matching",
+			existing: []Webhook{testWebhook("w1", a, "ledger")},
+			desired:  []WebhookSpec{{URL: a, Description: "ledger"}},
+			keep:     "w1",
+		},
+		{
+			name:     "delete unwanted",
+			existing: []Webhook{testWebhook("w1", a, ""), testWebhook("w2", b, "")},
+			desired:  []WebhookSpec{{URL: b}},
+			keep:     "w2",
+			del:      "w1",
+		},
+		{
+			name:     "delete everything",
+			existing: []Webhook{testWebhook("w1", a, "")},
+			del:      "w1",
+		},
+		{
+			name:     "description drift is recreated",
+			existing: []Webhook{testWebhook("w1", a, "old")},
+			desired:  []WebhookSpec{{URL: a, Description: "new"}},
+			del:      "w1",
+			create:   a + "#new",
+		},
+		{
+			name:     "URL drift is recreated",
+			existing: []Webhook{testWebhook("w1", a, "ledger")},
+			desired:  []WebhookSpec{{URL: b, Description: "ledger"}},
+			del:      "w1",
+			create:   b + "#ledger",
+		},
+		{
+			name:     "duplicates beyond the first are deleted",
+			existing: []Webhook{testWebhook("w1", a, ""), testWebhook("w2", a, "")},
+			desired:  []WebhookSpec{{URL: a}},
+			keep:     "w1",
+			del:      "w2",
+		},
+		{
+			name:    "duplicate specs create one webhook",
+			desired: []WebhookSpec{{URL: a}, {URL: a}},
+			create:  a + "#",
+		},
+		{
+			name:     "unmanaged webhooks are left alone",
+			existing: []Webhook{testWebhook("w1", a, "other tool"), testWebhook("w2", b, "")},
+			desired:  []WebhookSpec{{URL: b, Description: "ledger"}},
+			managed: func(w *Webhook) bool {
+				return w.Attributes.Description == nil || *w.Attributes.Description != "other tool"
+			},
+			del:    "w2",
+			create: b + "#ledger",
+		},
+	}
+	for _, tt := range tests {
+		plan := planWebhooks(tt.existing, tt.desired, tt.managed)
+		if got := webhookIDs(plan.Keep); got != tt.keep {
+			t.Errorf("%s: keep = %q, want %q", tt.name, got, tt.keep)
+		}
+		if got := webhookIDs(plan.Delete); got != tt.del {
+			t.Errorf("%s: delete = %q, want %q", tt.name, got, tt.del)
+		}
+		if got := specURLs(plan.Create); got != tt.create {
+			t.Errorf("%s: create = %q, want %q", tt.name, got, tt.create)
+		}
+		if empty := tt.del == "" && tt.create == ""; plan.IsEmpty() != empty {
+			t.Errorf("%s: IsEmpty = %t, want %t", tt.name, plan.IsEmpty(), empty)
+		}
+	}
+}
+
+func TestWebhookPlanSecretKeys(t *testing.T) {
+	w := testWebhook("w1", "https://a.example/hook", "")
+	w.Attributes.SecretKey = "s3cret"
+	plan := &WebhookPlan{Created: []Webhook{w}}
+
+	keys := plan.SecretKeys()
+	if len(keys) != 1 || keys["https://a.example/hook"] != "s3cret" {
+		t.Errorf("SecretKeys = %v", keys)
+	}
+	if got := (&WebhookPlan{}).SecretKeys(); len(got) != 0 {
+		t.Errorf("SecretKeys of an unapplied plan = %v", got)
+	}
+}
@@ -0,0 +1,127 @@
+package up
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// EventStore records the IDs of webhook events that have been handled so
+// redeliveries can be ignored. Implementations must be safe for concurrent use.
+type EventStore interface {
+	// MarkSeen records id and reports whether it had already been recorded
+	MarkSeen(ctx context.Context, id string) (seen bool, err error)
+	// Forget removes id so a later delivery of the event is handled again
+	Forget(ctx context.Context, id string) error
+}
+
+// MemoryEventStore is an in-memory EventStore that keeps at most capacity
+// IDs, evicting the least recently seen, and forgets IDs after a TTL
+type MemoryEventStore struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List // of *seenEvent, most recent at the front
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+type seenEvent struct {
+	id      string
+	expires time.Time
+}
+
+// NewMemoryEventStore returns an in-memory store. A zero ttl keeps IDs until
+// they are evicted by capacity.
+func NewMemoryEventStore(capacity int, ttl time.Duration) *MemoryEventStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &MemoryEventStore{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// MarkSeen implements EventStore
+func (s *MemoryEventStore) MarkSeen(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var expires time.Time
+	if s.ttl > 0 {
+		expires = now.Add(s.ttl)
+	}
+
+	if el, ok := s.entries[id]; ok {
+		entry := el.Value.(*seenEvent)
+		if entry.expires.IsZero() || now.Before(entry.expires) {
+			s.order.MoveToFront(el)
+			return true, nil
+		}
+		entry.expires = expires
+		s.order.MoveToFront(el)
+		return false, nil
+	}
+
+	s.entries[id] = s.order.PushFront(&seenEvent{id: id, expires: expires})
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+	return false, nil
+}
+
+// Forget implements EventStore
+func (s *MemoryEventStore) Forget(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[id]; ok {
+		s.remove(el)
+	}
+	return nil
+}
+
+// Len returns the number of IDs currently held
+func (s *MemoryEventStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *MemoryEventStore) remove(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.entries, el.Value.(*seenEvent).id)
+}
+
+// WithEventStore sets the store used to ignore redelivered events. A nil
+// store disables deduplication. By default an in-memory store remembering
+// 10,000 events for 24 hours is used. The maximum event age is lowered to a
+// MemoryEventStore's TTL; other stores must remember events for at least
+// the maximum event age, or a late redelivery is handled again.
+func WithEventStore(store EventStore) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.store = store
+	}
+}
+
+// WithMaxEventAge rejects events created more than maxAge ago, limiting how
+// long a captured payload can be replayed. The default is 24 hours. Zero
+// accepts any age, which is only safe with a store that never forgets.
+func WithMaxEventAge(maxAge time.Duration) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.maxEventAge = maxAge
+	}
+}
+
+// WithDuplicateHook is called for every delivery ignored as a duplicate, for example to record a metric
+func WithDuplicateHook(fn func(ctx context.Context, event *WebhookEvent)) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.onDuplicate = fn
+	}
+}
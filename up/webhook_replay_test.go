@@ -0,0 +1,177 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandlerDuplicate(t *testing.T) {
+	var handled, duplicates int
+	h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+		handled++
+		return nil
+	}, WithDuplicateHook(func(ctx context.Context, event *WebhookEvent) { duplicates++ }))
+
+	body := eventBody("event-1", WebhookEventTransactionCreated, time.Now())
+	for i := range 3 {
+		if rec := deliver(h, testSecret, body); rec.Code != http.StatusOK {
+			t.Errorf("delivery %d: status = %d, want 200", i+1, rec.Code)
+		}
+	}
+	if handled != 1 || duplicates != 2 {
+		t.Errorf("handled %d times with %d duplicates, want 1 and 2", handled, duplicates)
+	}
+
+	if rec := deliver(h, testSecret, eventBody("event-2", WebhookEventTransactionCreated, time.Now())); rec.Code != http.StatusOK || handled != 2 {
+		t.Errorf("new event: status %d, handled %d times, want 200 and 2", rec.Code, handled)
+	}
+}
+
+func TestWebhookHandlerForgetsFailedEvents(t *testing.T) {
+	var calls int
+	h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	body := eventBody("event-1", WebhookEventTransactionSettled, time.Now())
+	if rec := deliver(h, testSecret, body); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("first delivery: status = %d, want 500", rec.Code)
+	}
+	if rec := deliver(h, testSecret, body); rec.Code != http.StatusOK {
+		t.Fatalf("retry: status = %d, want 200", rec.Code)
+	}
+	if calls != 2 {
+		t.Errorf("callback called %d times, want 2", calls)
+	}
+	// The successful retry is remembered
+	deliver(h, testSecret, body)
+	if calls != 2 {
+		t.Errorf("callback called %d times after a third delivery, want 2", calls)
+	}
+}
+
+func TestWebhookHandlerConcurrentDuplicate(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	body := eventBody("event-1", WebhookEventTransactionSettled, time.Now())
+	first := make(chan int)
+	go func() { first <- deliver(h, testSecret, body).Code }()
+	<-started
+
+	// The first delivery may still fail, so the duplicate must not be acknowledged
+	rec := deliver(h, testSecret, body)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("duplicate during handling: status = %d, want 409", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("duplicate during handling has no Retry-After")
+	}
+
+	close(release)
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Fatalf("first delivery: status = %d, want 500", code)
+	}
+	// Up's retry after the failure is handled
+	if rec := deliver(h, testSecret, body); rec.Code != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("retry: status %d after %d calls, want 200 after 2", rec.Code, calls.Load())
+	}
+}
+
+func TestWebhookHandlerMaxEventAge(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []WebhookHandlerOption
+		age  time.Duration
+		want int
+	}{
+		{"default accepts recent events", nil, time.Hour, http.StatusOK},
+		{"default rejects events older than the store remembers", nil, 25 * time.Hour, http.StatusBadRequest},
+		{"explicit max age", []WebhookHandlerOption{WithMaxEventAge(time.Minute)}, time.Hour, http.StatusBadRequest},
+		{"max age lowered to a memory store's TTL", []WebhookHandlerOption{
+			WithEventStore(NewMemoryEventStore(100, time.Hour)), WithMaxEventAge(48 * time.Hour),
+		}, 2 * time.Hour, http.StatusBadRequest},
+		{"zero max age is lowered to a memory store's TTL", []WebhookHandlerOption{
+			WithEventStore(NewMemoryEventStore(100, time.Hour)), WithMaxEventAge(0),
+		}, 2 * time.Hour, http.StatusBadRequest},
+		{"memory store without a TTL", []WebhookHandlerOption{
+			WithEventStore(NewMemoryEventStore(100, 0)), WithMaxEventAge(0),
+		}, 30 * 24 * time.Hour, http.StatusOK},
+		{"no store and no max age", []WebhookHandlerOption{WithEventStore(nil), WithMaxEventAge(0)}, 30 * 24 * time.Hour, http.StatusOK},
+	}
+	for _, tt := range tests {
+		var called bool
+		h := NewWebhookHandler(testSecret, func(ctx context.Context, event *WebhookEvent) error {
+			called = true
+			return nil
+		}, tt.opts...)
+
+		rec := deliver(h, testSecret, eventBody("event-1", WebhookEventTransactionCreated, time.Now().Add(-tt.age)))
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+		if called != (tt.want == http.StatusOK) {
+			t.Errorf("%s: callback called = %v", tt.name, called)
+		}
+	}
+}
+
+func TestMemoryEventStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryEventStore(2, time.Hour)
+	s.now = func() time.Time { return now }
+
+	mark := func(id string) bool {
+		t.Helper()
+		seen, err := s.MarkSeen(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return seen
+	}
+
+	if mark("a") || !mark("a") {
+		t.Error("a was not remembered")
+	}
+
+	// Capacity evicts the least recently seen
+	mark("b")
+	mark("a")
+	mark("c")
+	if s.Len() != 2 || mark("b") {
+		t.Errorf("b was not evicted, %d IDs held", s.Len())
+	}
+
+	// IDs expire after the TTL
+	now = now.Add(2 * time.Hour)
+	if mark("c") {
+		t.Error("c was remembered past its TTL")
+	}
+	if !mark("c") {
+		t.Error("c was not remembered again after expiring")
+	}
+
+	if err := s.Forget(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if mark("c") {
+		t.Error("c was remembered after Forget")
+	}
+}
@@ -45,10 +45,7 @@ type WebhookRelationships struct {
 }
 
 // WebhookListResponse represents the response from listing webhooks
-type WebhookListResponse struct {
-	Data  []Webhook `json:"data"`
-	Links Links     `json:"links"`
-}
+type WebhookListResponse = ListResponse[Webhook]
 
 // WebhookResponse represents the response for a single webhook
 type WebhookResponse struct {
@@ -109,10 +106,7 @@ type WebhookDeliveryLogRelationships struct {
 }
 
 // WebhookDeliveryLogListResponse represents the response from listing webhook delivery logs
-type WebhookDeliveryLogListResponse struct {
-	Data  []WebhookDeliveryLog `json:"data"`
-	Links Links                `json:"links"`
-}
+type WebhookDeliveryLogListResponse = ListResponse[WebhookDeliveryLog]
 
 // WebhookEvent represents a webhook event
 type WebhookEvent struct {
@@ -120,11 +114,30 @@ type WebhookEvent struct {
 	ID            string                 `json:"id"`
 	Attributes    WebhookEventAttributes `json:"attributes"`
 	Relationships struct {
-		Webhook     WebhookRelationships      `json:"webhook"`
-		Transaction *TransactionRelationships `json:"transaction,omitempty"`
+		Webhook     WebhookEventRelationship  `json:"webhook"`
+		Transaction *WebhookEventRelationship `json:"transaction,omitempty"`
 	} `json:"relationships"`
 }
 
+// WebhookEventRelationship represents a resource related to a webhook event
+type WebhookEventRelationship struct {
+	Data struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"data"`
+	Links *struct {
+		Related string `json:"related"`
+	} `json:"links,omitempty"`
+}
+
+// TransactionID returns the ID of the transaction the event relates to, or "" for events such as PING
+func (e *WebhookEvent) TransactionID() string {
+	if e.Relationships.Transaction == nil {
+		return ""
+	}
+	return e.Relationships.Transaction.Data.ID
+}
+
 // WebhookEventAttributes represents attributes of a webhook event
 type WebhookEventAttributes struct {
 	EventType WebhookEventTypeEnum `json:"eventType"`
@@ -138,6 +151,7 @@ type WebhookEventResponse struct {
 
 // List returns a list of all webhooks
 func (s *WebhooksService) List(ctx context.Context, opts *ListOptions) (*WebhookListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.list")
 	u := "webhooks"
 	if opts != nil {
 		var err error
@@ -147,22 +161,12 @@ func (s *WebhooksService) List(ctx context.Context, opts *ListOptions) (*Webhook
 		}
 	}
 
-	req, err := s.client.newRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var response WebhookListResponse
-	resp, err := s.client.do(ctx, req, &response)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return &response, resp, nil
+	return paginate[Webhook](ctx, s.client, u, opts)
 }
 
 // Get returns a specific webhook by ID
 func (s *WebhooksService) Get(ctx context.Context, webhookID string) (*Webhook, *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.get")
 	u := fmt.Sprintf("webhooks/%s", webhookID)
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
@@ -180,6 +184,7 @@ func (s *WebhooksService) Get(ctx context.Context, webhookID string) (*Webhook,
 
 // Create creates a new webhook
 func (s *WebhooksService) Create(ctx context.Context, url string, description *string) (*Webhook, *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.create")
 	u := "webhooks"
 
 	createReq := &WebhookCreateRequest{}
@@ -204,6 +209,7 @@ func (s *WebhooksService) Create(ctx context.Context, url string, description *s
 
 // Delete deletes a webhook
 func (s *WebhooksService) Delete(ctx context.Context, webhookID string) (*http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.delete")
 	u := fmt.Sprintf("webhooks/%s", webhookID)
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
@@ -215,6 +221,7 @@ func (s *WebhooksService) Delete(ctx context.Context, webhookID string) (*http.R
 
 // Ping sends a ping event to a webhook
 func (s *WebhooksService) Ping(ctx context.Context, webhookID string) (*WebhookEvent, *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.ping")
 	u := fmt.Sprintf("webhooks/%s/ping", webhookID)
 	req, err := s.client.newRequest("POST", u, nil)
 	if err != nil {
@@ -232,6 +239,7 @@ func (s *WebhooksService) Ping(ctx context.Context, webhookID string) (*WebhookE
 
 // ListLogs returns a list of delivery logs for a webhook
 func (s *WebhooksService) ListLogs(ctx context.Context, webhookID string, opts *ListOptions) (*WebhookDeliveryLogListResponse, *http.Response, error) {
+	ctx = withOperation(ctx, "webhooks.list_logs")
 	u := fmt.Sprintf("webhooks/%s/logs", webhookID)
 	if opts != nil {
 		var err error
@@ -241,16 +249,5 @@ func (s *WebhooksService) ListLogs(ctx context.Context, webhookID string, opts *
 		}
 	}
 
-	req, err := s.client.newRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var response WebhookDeliveryLogListResponse
-	resp, err := s.client.do(ctx, req, &response)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return &response, resp, nil
+	return paginate[WebhookDeliveryLog](ctx, s.client, u, opts)
 }
@@ -0,0 +1,83 @@
+package up
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWebhooksListMergesPages(t *testing.T) {
+	c := newTestClient(t, pagedHandler(5, func(i int) any {
+		return map[string]any{
+			"type":       "webhooks",
+			"id":         "webhook-" + strconv.Itoa(i),
+			"attributes": map[string]any{"url": "https://example.com/" + strconv.Itoa(i)},
+		}
+	}))
+
+	resp, _, err := c.Webhooks.List(t.Context(), &ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.PagesFetched != 3 || resp.Links.Next != "" {
+		t.Errorf("PagesFetched = %d, Links.Next = %q, want 3 pages and no next link", resp.PagesFetched, resp.Links.Next)
+	}
+	if len(resp.Data) != 5 {
+		t.Fatalf("got %d webhooks, want 5", len(resp.Data))
+	}
+	for i, wh := range resp.Data {
+		if want := "https://example.com/" + strconv.Itoa(i); wh.ID != "webhook-"+strconv.Itoa(i) || wh.Attributes.URL != want {
+			t.Errorf("webhook %d = %s %s", i, wh.ID, wh.Attributes.URL)
+		}
+	}
+}
+
+func TestWebhooksListLogsMergesPages(t *testing.T) {
+	logs := pagedHandler(7, func(i int) any {
+		return map[string]any{
+			"type":       "webhook-delivery-logs",
+			"id":         "log-" + strconv.Itoa(i),
+			"attributes": map[string]any{"deliveryStatus": "DELIVERED"},
+		}
+	})
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/webhooks/webhook-1/logs") {
+			t.Errorf("requested %s, want the webhook's logs", r.URL.Path)
+		}
+		logs.ServeHTTP(w, r)
+	}))
+
+	resp, _, err := c.Webhooks.ListLogs(t.Context(), "webhook-1", &ListOptions{PageSize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.PagesFetched != 3 || resp.Links.Next != "" {
+		t.Errorf("PagesFetched = %d, Links.Next = %q, want 3 pages and no next link", resp.PagesFetched, resp.Links.Next)
+	}
+	if len(resp.Data) != 7 {
+		t.Fatalf("got %d logs, want 7", len(resp.Data))
+	}
+	for i, log := range resp.Data {
+		if want := "log-" + strconv.Itoa(i); log.ID != want || log.Attributes.DeliveryStatus != WebhookDeliveryStatusDelivered {
+			t.Errorf("log %d = %s %s, want %s DELIVERED", i, log.ID, log.Attributes.DeliveryStatus, want)
+		}
+	}
+}
+
+func TestWebhooksListLogsMaxItems(t *testing.T) {
+	c := newTestClient(t, pagedHandler(7, func(i int) any {
+		return map[string]any{"type": "webhook-delivery-logs", "id": "log-" + strconv.Itoa(i)}
+	}))
+
+	resp, _, err := c.Webhooks.ListLogs(t.Context(), "webhook-1", &ListOptions{PageSize: 3, MaxItems: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data) != 5 || resp.PagesFetched != 2 {
+		t.Errorf("got %d logs in %d pages, want 5 in 2", len(resp.Data), resp.PagesFetched)
+	}
+	if got := cursorFromLink(resp.Links.Next, "page[after]"); got != "5" {
+		t.Errorf("Links.Next = %q, want it to resume at 5", resp.Links.Next)
+	}
+}
@@ -0,0 +1,14 @@
+package upmock
+
+import "github.com/jordanst3wart/up-client/up"
+
+// The mocks are generated, so check they still implement the interfaces.
+// If this fails to build, regenerate them with go generate ./up.
+var (
+	_ up.AccountsAPI     = (*MockAccountsAPI)(nil)
+	_ up.TransactionsAPI = (*MockTransactionsAPI)(nil)
+	_ up.TagsAPI         = (*MockTagsAPI)(nil)
+	_ up.CategoriesAPI   = (*MockCategoriesAPI)(nil)
+	_ up.WebhooksAPI     = (*MockWebhooksAPI)(nil)
+	_ up.UtilityAPI      = (*MockUtilityAPI)(nil)
+)
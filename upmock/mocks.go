@@ -0,0 +1,1544 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/jordanst3wart/up-client/up (interfaces: AccountsAPI,TransactionsAPI,TagsAPI,CategoriesAPI,WebhooksAPI,UtilityAPI)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../upmock/mocks.go -package=upmock -typed . AccountsAPI,TransactionsAPI,TagsAPI,CategoriesAPI,WebhooksAPI,UtilityAPI
+//
+
+// Package upmock is a generated GoMock package.
+package upmock
+
+import (
+	context "context"
+	iter "iter"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	up "github.com/jordanst3wart/up-client/up"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountsAPI is a mock of AccountsAPI interface.
+type MockAccountsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountsAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountsAPIMockRecorder is the mock recorder for MockAccountsAPI.
+type MockAccountsAPIMockRecorder struct {
+	mock *MockAccountsAPI
+}
+
+// NewMockAccountsAPI creates a new mock instance.
+func NewMockAccountsAPI(ctrl *gomock.Controller) *MockAccountsAPI {
+	mock := &MockAccountsAPI{ctrl: ctrl}
+	mock.recorder = &MockAccountsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountsAPI) EXPECT() *MockAccountsAPIMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockAccountsAPI) All(ctx context.Context, opts *up.ListAccountsOptions) iter.Seq2[up.Account, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx, opts)
+	ret0, _ := ret[0].(iter.Seq2[up.Account, error])
+	return ret0
+}
+
+// All indicates an expected call of All.
+func (mr *MockAccountsAPIMockRecorder) All(ctx, opts any) *MockAccountsAPIAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockAccountsAPI)(nil).All), ctx, opts)
+	return &MockAccountsAPIAllCall{Call: call}
+}
+
+// MockAccountsAPIAllCall wrap *gomock.Call
+type MockAccountsAPIAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAccountsAPIAllCall) Return(arg0 iter.Seq2[up.Account, error]) *MockAccountsAPIAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAccountsAPIAllCall) Do(f func(context.Context, *up.ListAccountsOptions) iter.Seq2[up.Account, error]) *MockAccountsAPIAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAccountsAPIAllCall) DoAndReturn(f func(context.Context, *up.ListAccountsOptions) iter.Seq2[up.Account, error]) *MockAccountsAPIAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockAccountsAPI) Get(ctx context.Context, accountID string) (*up.Account, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, accountID)
+	ret0, _ := ret[0].(*up.Account)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAccountsAPIMockRecorder) Get(ctx, accountID any) *MockAccountsAPIGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAccountsAPI)(nil).Get), ctx, accountID)
+	return &MockAccountsAPIGetCall{Call: call}
+}
+
+// MockAccountsAPIGetCall wrap *gomock.Call
+type MockAccountsAPIGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAccountsAPIGetCall) Return(arg0 *up.Account, arg1 *http.Response, arg2 error) *MockAccountsAPIGetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAccountsAPIGetCall) Do(f func(context.Context, string) (*up.Account, *http.Response, error)) *MockAccountsAPIGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAccountsAPIGetCall) DoAndReturn(f func(context.Context, string) (*up.Account, *http.Response, error)) *MockAccountsAPIGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockAccountsAPI) List(ctx context.Context, opts *up.ListAccountsOptions) (*up.AccountListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].(*up.AccountListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAccountsAPIMockRecorder) List(ctx, opts any) *MockAccountsAPIListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAccountsAPI)(nil).List), ctx, opts)
+	return &MockAccountsAPIListCall{Call: call}
+}
+
+// MockAccountsAPIListCall wrap *gomock.Call
+type MockAccountsAPIListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAccountsAPIListCall) Return(arg0 *up.AccountListResponse, arg1 *http.Response, arg2 error) *MockAccountsAPIListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAccountsAPIListCall) Do(f func(context.Context, *up.ListAccountsOptions) (*up.AccountListResponse, *http.Response, error)) *MockAccountsAPIListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAccountsAPIListCall) DoAndReturn(f func(context.Context, *up.ListAccountsOptions) (*up.AccountListResponse, *http.Response, error)) *MockAccountsAPIListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPage mocks base method.
+func (m *MockAccountsAPI) ListPage(ctx context.Context, opts *up.ListAccountsOptions) (*up.Page[up.Account], *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, opts)
+	ret0, _ := ret[0].(*up.Page[up.Account])
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockAccountsAPIMockRecorder) ListPage(ctx, opts any) *MockAccountsAPIListPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockAccountsAPI)(nil).ListPage), ctx, opts)
+	return &MockAccountsAPIListPageCall{Call: call}
+}
+
+// MockAccountsAPIListPageCall wrap *gomock.Call
+type MockAccountsAPIListPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAccountsAPIListPageCall) Return(arg0 *up.Page[up.Account], arg1 *http.Response, arg2 error) *MockAccountsAPIListPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAccountsAPIListPageCall) Do(f func(context.Context, *up.ListAccountsOptions) (*up.Page[up.Account], *http.Response, error)) *MockAccountsAPIListPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAccountsAPIListPageCall) DoAndReturn(f func(context.Context, *up.ListAccountsOptions) (*up.Page[up.Account], *http.Response, error)) *MockAccountsAPIListPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTransactionsAPI is a mock of TransactionsAPI interface.
+type MockTransactionsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionsAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionsAPIMockRecorder is the mock recorder for MockTransactionsAPI.
+type MockTransactionsAPIMockRecorder struct {
+	mock *MockTransactionsAPI
+}
+
+// NewMockTransactionsAPI creates a new mock instance.
+func NewMockTransactionsAPI(ctrl *gomock.Controller) *MockTransactionsAPI {
+	mock := &MockTransactionsAPI{ctrl: ctrl}
+	mock.recorder = &MockTransactionsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionsAPI) EXPECT() *MockTransactionsAPIMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockTransactionsAPI) All(ctx context.Context, opts *up.ListTransactionsOptions) iter.Seq2[up.Transaction, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx, opts)
+	ret0, _ := ret[0].(iter.Seq2[up.Transaction, error])
+	return ret0
+}
+
+// All indicates an expected call of All.
+func (mr *MockTransactionsAPIMockRecorder) All(ctx, opts any) *MockTransactionsAPIAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockTransactionsAPI)(nil).All), ctx, opts)
+	return &MockTransactionsAPIAllCall{Call: call}
+}
+
+// MockTransactionsAPIAllCall wrap *gomock.Call
+type MockTransactionsAPIAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIAllCall) Return(arg0 iter.Seq2[up.Transaction, error]) *MockTransactionsAPIAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIAllCall) Do(f func(context.Context, *up.ListTransactionsOptions) iter.Seq2[up.Transaction, error]) *MockTransactionsAPIAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIAllCall) DoAndReturn(f func(context.Context, *up.ListTransactionsOptions) iter.Seq2[up.Transaction, error]) *MockTransactionsAPIAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AllByAccount mocks base method.
+func (m *MockTransactionsAPI) AllByAccount(ctx context.Context, accountID string, opts *up.ListTransactionsOptions) iter.Seq2[up.Transaction, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllByAccount", ctx, accountID, opts)
+	ret0, _ := ret[0].(iter.Seq2[up.Transaction, error])
+	return ret0
+}
+
+// AllByAccount indicates an expected call of AllByAccount.
+func (mr *MockTransactionsAPIMockRecorder) AllByAccount(ctx, accountID, opts any) *MockTransactionsAPIAllByAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllByAccount", reflect.TypeOf((*MockTransactionsAPI)(nil).AllByAccount), ctx, accountID, opts)
+	return &MockTransactionsAPIAllByAccountCall{Call: call}
+}
+
+// MockTransactionsAPIAllByAccountCall wrap *gomock.Call
+type MockTransactionsAPIAllByAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIAllByAccountCall) Return(arg0 iter.Seq2[up.Transaction, error]) *MockTransactionsAPIAllByAccountCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIAllByAccountCall) Do(f func(context.Context, string, *up.ListTransactionsOptions) iter.Seq2[up.Transaction, error]) *MockTransactionsAPIAllByAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIAllByAccountCall) DoAndReturn(f func(context.Context, string, *up.ListTransactionsOptions) iter.Seq2[up.Transaction, error]) *MockTransactionsAPIAllByAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockTransactionsAPI) Get(ctx context.Context, transactionID string) (*up.Transaction, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, transactionID)
+	ret0, _ := ret[0].(*up.Transaction)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTransactionsAPIMockRecorder) Get(ctx, transactionID any) *MockTransactionsAPIGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTransactionsAPI)(nil).Get), ctx, transactionID)
+	return &MockTransactionsAPIGetCall{Call: call}
+}
+
+// MockTransactionsAPIGetCall wrap *gomock.Call
+type MockTransactionsAPIGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIGetCall) Return(arg0 *up.Transaction, arg1 *http.Response, arg2 error) *MockTransactionsAPIGetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIGetCall) Do(f func(context.Context, string) (*up.Transaction, *http.Response, error)) *MockTransactionsAPIGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIGetCall) DoAndReturn(f func(context.Context, string) (*up.Transaction, *http.Response, error)) *MockTransactionsAPIGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockTransactionsAPI) List(ctx context.Context, opts *up.ListTransactionsOptions) (*up.TransactionListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].(*up.TransactionListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockTransactionsAPIMockRecorder) List(ctx, opts any) *MockTransactionsAPIListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTransactionsAPI)(nil).List), ctx, opts)
+	return &MockTransactionsAPIListCall{Call: call}
+}
+
+// MockTransactionsAPIListCall wrap *gomock.Call
+type MockTransactionsAPIListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIListCall) Return(arg0 *up.TransactionListResponse, arg1 *http.Response, arg2 error) *MockTransactionsAPIListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIListCall) Do(f func(context.Context, *up.ListTransactionsOptions) (*up.TransactionListResponse, *http.Response, error)) *MockTransactionsAPIListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIListCall) DoAndReturn(f func(context.Context, *up.ListTransactionsOptions) (*up.TransactionListResponse, *http.Response, error)) *MockTransactionsAPIListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListByAccount mocks base method.
+func (m *MockTransactionsAPI) ListByAccount(ctx context.Context, accountID string, opts *up.ListTransactionsOptions) (*up.TransactionListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccount", ctx, accountID, opts)
+	ret0, _ := ret[0].(*up.TransactionListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByAccount indicates an expected call of ListByAccount.
+func (mr *MockTransactionsAPIMockRecorder) ListByAccount(ctx, accountID, opts any) *MockTransactionsAPIListByAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccount", reflect.TypeOf((*MockTransactionsAPI)(nil).ListByAccount), ctx, accountID, opts)
+	return &MockTransactionsAPIListByAccountCall{Call: call}
+}
+
+// MockTransactionsAPIListByAccountCall wrap *gomock.Call
+type MockTransactionsAPIListByAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIListByAccountCall) Return(arg0 *up.TransactionListResponse, arg1 *http.Response, arg2 error) *MockTransactionsAPIListByAccountCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIListByAccountCall) Do(f func(context.Context, string, *up.ListTransactionsOptions) (*up.TransactionListResponse, *http.Response, error)) *MockTransactionsAPIListByAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIListByAccountCall) DoAndReturn(f func(context.Context, string, *up.ListTransactionsOptions) (*up.TransactionListResponse, *http.Response, error)) *MockTransactionsAPIListByAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPage mocks base method.
+func (m *MockTransactionsAPI) ListPage(ctx context.Context, opts *up.ListTransactionsOptions) (*up.Page[up.Transaction], *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, opts)
+	ret0, _ := ret[0].(*up.Page[up.Transaction])
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockTransactionsAPIMockRecorder) ListPage(ctx, opts any) *MockTransactionsAPIListPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockTransactionsAPI)(nil).ListPage), ctx, opts)
+	return &MockTransactionsAPIListPageCall{Call: call}
+}
+
+// MockTransactionsAPIListPageCall wrap *gomock.Call
+type MockTransactionsAPIListPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIListPageCall) Return(arg0 *up.Page[up.Transaction], arg1 *http.Response, arg2 error) *MockTransactionsAPIListPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIListPageCall) Do(f func(context.Context, *up.ListTransactionsOptions) (*up.Page[up.Transaction], *http.Response, error)) *MockTransactionsAPIListPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIListPageCall) DoAndReturn(f func(context.Context, *up.ListTransactionsOptions) (*up.Page[up.Transaction], *http.Response, error)) *MockTransactionsAPIListPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPageByAccount mocks base method.
+func (m *MockTransactionsAPI) ListPageByAccount(ctx context.Context, accountID string, opts *up.ListTransactionsOptions) (*up.Page[up.Transaction], *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPageByAccount", ctx, accountID, opts)
+	ret0, _ := ret[0].(*up.Page[up.Transaction])
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPageByAccount indicates an expected call of ListPageByAccount.
+func (mr *MockTransactionsAPIMockRecorder) ListPageByAccount(ctx, accountID, opts any) *MockTransactionsAPIListPageByAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPageByAccount", reflect.TypeOf((*MockTransactionsAPI)(nil).ListPageByAccount), ctx, accountID, opts)
+	return &MockTransactionsAPIListPageByAccountCall{Call: call}
+}
+
+// MockTransactionsAPIListPageByAccountCall wrap *gomock.Call
+type MockTransactionsAPIListPageByAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTransactionsAPIListPageByAccountCall) Return(arg0 *up.Page[up.Transaction], arg1 *http.Response, arg2 error) *MockTransactionsAPIListPageByAccountCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTransactionsAPIListPageByAccountCall) Do(f func(context.Context, string, *up.ListTransactionsOptions) (*up.Page[up.Transaction], *http.Response, error)) *MockTransactionsAPIListPageByAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTransactionsAPIListPageByAccountCall) DoAndReturn(f func(context.Context, string, *up.ListTransactionsOptions) (*up.Page[up.Transaction], *http.Response, error)) *MockTransactionsAPIListPageByAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTagsAPI is a mock of TagsAPI interface.
+type MockTagsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockTagsAPIMockRecorder is the mock recorder for MockTagsAPI.
+type MockTagsAPIMockRecorder struct {
+	mock *MockTagsAPI
+}
+
+// NewMockTagsAPI creates a new mock instance.
+func NewMockTagsAPI(ctrl *gomock.Controller) *MockTagsAPI {
+	mock := &MockTagsAPI{ctrl: ctrl}
+	mock.recorder = &MockTagsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsAPI) EXPECT() *MockTagsAPIMockRecorder {
+	return m.recorder
+}
+
+// AddToTransaction mocks base method.
+func (m *MockTagsAPI) AddToTransaction(ctx context.Context, transactionID string, tagIDs []string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToTransaction", ctx, transactionID, tagIDs)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToTransaction indicates an expected call of AddToTransaction.
+func (mr *MockTagsAPIMockRecorder) AddToTransaction(ctx, transactionID, tagIDs any) *MockTagsAPIAddToTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToTransaction", reflect.TypeOf((*MockTagsAPI)(nil).AddToTransaction), ctx, transactionID, tagIDs)
+	return &MockTagsAPIAddToTransactionCall{Call: call}
+}
+
+// MockTagsAPIAddToTransactionCall wrap *gomock.Call
+type MockTagsAPIAddToTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTagsAPIAddToTransactionCall) Return(arg0 *http.Response, arg1 error) *MockTagsAPIAddToTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTagsAPIAddToTransactionCall) Do(f func(context.Context, string, []string) (*http.Response, error)) *MockTagsAPIAddToTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTagsAPIAddToTransactionCall) DoAndReturn(f func(context.Context, string, []string) (*http.Response, error)) *MockTagsAPIAddToTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// All mocks base method.
+func (m *MockTagsAPI) All(ctx context.Context, opts *up.ListOptions) iter.Seq2[up.Tag, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx, opts)
+	ret0, _ := ret[0].(iter.Seq2[up.Tag, error])
+	return ret0
+}
+
+// All indicates an expected call of All.
+func (mr *MockTagsAPIMockRecorder) All(ctx, opts any) *MockTagsAPIAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockTagsAPI)(nil).All), ctx, opts)
+	return &MockTagsAPIAllCall{Call: call}
+}
+
+// MockTagsAPIAllCall wrap *gomock.Call
+type MockTagsAPIAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTagsAPIAllCall) Return(arg0 iter.Seq2[up.Tag, error]) *MockTagsAPIAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTagsAPIAllCall) Do(f func(context.Context, *up.ListOptions) iter.Seq2[up.Tag, error]) *MockTagsAPIAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTagsAPIAllCall) DoAndReturn(f func(context.Context, *up.ListOptions) iter.Seq2[up.Tag, error]) *MockTagsAPIAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockTagsAPI) List(ctx context.Context, opts *up.ListOptions) (*up.TagListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].(*up.TagListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagsAPIMockRecorder) List(ctx, opts any) *MockTagsAPIListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagsAPI)(nil).List), ctx, opts)
+	return &MockTagsAPIListCall{Call: call}
+}
+
+// MockTagsAPIListCall wrap *gomock.Call
+type MockTagsAPIListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTagsAPIListCall) Return(arg0 *up.TagListResponse, arg1 *http.Response, arg2 error) *MockTagsAPIListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTagsAPIListCall) Do(f func(context.Context, *up.ListOptions) (*up.TagListResponse, *http.Response, error)) *MockTagsAPIListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTagsAPIListCall) DoAndReturn(f func(context.Context, *up.ListOptions) (*up.TagListResponse, *http.Response, error)) *MockTagsAPIListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPage mocks base method.
+func (m *MockTagsAPI) ListPage(ctx context.Context, opts *up.ListOptions) (*up.Page[up.Tag], *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, opts)
+	ret0, _ := ret[0].(*up.Page[up.Tag])
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockTagsAPIMockRecorder) ListPage(ctx, opts any) *MockTagsAPIListPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockTagsAPI)(nil).ListPage), ctx, opts)
+	return &MockTagsAPIListPageCall{Call: call}
+}
+
+// MockTagsAPIListPageCall wrap *gomock.Call
+type MockTagsAPIListPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTagsAPIListPageCall) Return(arg0 *up.Page[up.Tag], arg1 *http.Response, arg2 error) *MockTagsAPIListPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTagsAPIListPageCall) Do(f func(context.Context, *up.ListOptions) (*up.Page[up.Tag], *http.Response, error)) *MockTagsAPIListPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTagsAPIListPageCall) DoAndReturn(f func(context.Context, *up.ListOptions) (*up.Page[up.Tag], *http.Response, error)) *MockTagsAPIListPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveFromTransaction mocks base method.
+func (m *MockTagsAPI) RemoveFromTransaction(ctx context.Context, transactionID string, tagIDs []string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromTransaction", ctx, transactionID, tagIDs)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromTransaction indicates an expected call of RemoveFromTransaction.
+func (mr *MockTagsAPIMockRecorder) RemoveFromTransaction(ctx, transactionID, tagIDs any) *MockTagsAPIRemoveFromTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromTransaction", reflect.TypeOf((*MockTagsAPI)(nil).RemoveFromTransaction), ctx, transactionID, tagIDs)
+	return &MockTagsAPIRemoveFromTransactionCall{Call: call}
+}
+
+// MockTagsAPIRemoveFromTransactionCall wrap *gomock.Call
+type MockTagsAPIRemoveFromTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTagsAPIRemoveFromTransactionCall) Return(arg0 *http.Response, arg1 error) *MockTagsAPIRemoveFromTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTagsAPIRemoveFromTransactionCall) Do(f func(context.Context, string, []string) (*http.Response, error)) *MockTagsAPIRemoveFromTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTagsAPIRemoveFromTransactionCall) DoAndReturn(f func(context.Context, string, []string) (*http.Response, error)) *MockTagsAPIRemoveFromTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCategoriesAPI is a mock of CategoriesAPI interface.
+type MockCategoriesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoriesAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoriesAPIMockRecorder is the mock recorder for MockCategoriesAPI.
+type MockCategoriesAPIMockRecorder struct {
+	mock *MockCategoriesAPI
+}
+
+// NewMockCategoriesAPI creates a new mock instance.
+func NewMockCategoriesAPI(ctrl *gomock.Controller) *MockCategoriesAPI {
+	mock := &MockCategoriesAPI{ctrl: ctrl}
+	mock.recorder = &MockCategoriesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoriesAPI) EXPECT() *MockCategoriesAPIMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCategoriesAPI) Get(ctx context.Context, categoryID string) (*up.Category, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, categoryID)
+	ret0, _ := ret[0].(*up.Category)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCategoriesAPIMockRecorder) Get(ctx, categoryID any) *MockCategoriesAPIGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCategoriesAPI)(nil).Get), ctx, categoryID)
+	return &MockCategoriesAPIGetCall{Call: call}
+}
+
+// MockCategoriesAPIGetCall wrap *gomock.Call
+type MockCategoriesAPIGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCategoriesAPIGetCall) Return(arg0 *up.Category, arg1 *http.Response, arg2 error) *MockCategoriesAPIGetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCategoriesAPIGetCall) Do(f func(context.Context, string) (*up.Category, *http.Response, error)) *MockCategoriesAPIGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCategoriesAPIGetCall) DoAndReturn(f func(context.Context, string) (*up.Category, *http.Response, error)) *MockCategoriesAPIGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockCategoriesAPI) List(ctx context.Context, opts *up.ListCategoriesOptions) (*up.CategoryListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].(*up.CategoryListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockCategoriesAPIMockRecorder) List(ctx, opts any) *MockCategoriesAPIListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCategoriesAPI)(nil).List), ctx, opts)
+	return &MockCategoriesAPIListCall{Call: call}
+}
+
+// MockCategoriesAPIListCall wrap *gomock.Call
+type MockCategoriesAPIListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCategoriesAPIListCall) Return(arg0 *up.CategoryListResponse, arg1 *http.Response, arg2 error) *MockCategoriesAPIListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCategoriesAPIListCall) Do(f func(context.Context, *up.ListCategoriesOptions) (*up.CategoryListResponse, *http.Response, error)) *MockCategoriesAPIListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCategoriesAPIListCall) DoAndReturn(f func(context.Context, *up.ListCategoriesOptions) (*up.CategoryListResponse, *http.Response, error)) *MockCategoriesAPIListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveTransactionCategory mocks base method.
+func (m *MockCategoriesAPI) RemoveTransactionCategory(ctx context.Context, transactionID string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTransactionCategory", ctx, transactionID)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTransactionCategory indicates an expected call of RemoveTransactionCategory.
+func (mr *MockCategoriesAPIMockRecorder) RemoveTransactionCategory(ctx, transactionID any) *MockCategoriesAPIRemoveTransactionCategoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTransactionCategory", reflect.TypeOf((*MockCategoriesAPI)(nil).RemoveTransactionCategory), ctx, transactionID)
+	return &MockCategoriesAPIRemoveTransactionCategoryCall{Call: call}
+}
+
+// MockCategoriesAPIRemoveTransactionCategoryCall wrap *gomock.Call
+type MockCategoriesAPIRemoveTransactionCategoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCategoriesAPIRemoveTransactionCategoryCall) Return(arg0 *http.Response, arg1 error) *MockCategoriesAPIRemoveTransactionCategoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCategoriesAPIRemoveTransactionCategoryCall) Do(f func(context.Context, string) (*http.Response, error)) *MockCategoriesAPIRemoveTransactionCategoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCategoriesAPIRemoveTransactionCategoryCall) DoAndReturn(f func(context.Context, string) (*http.Response, error)) *MockCategoriesAPIRemoveTransactionCategoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateTransactionCategory mocks base method.
+func (m *MockCategoriesAPI) UpdateTransactionCategory(ctx context.Context, transactionID, categoryID string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionCategory", ctx, transactionID, categoryID)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionCategory indicates an expected call of UpdateTransactionCategory.
+func (mr *MockCategoriesAPIMockRecorder) UpdateTransactionCategory(ctx, transactionID, categoryID any) *MockCategoriesAPIUpdateTransactionCategoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionCategory", reflect.TypeOf((*MockCategoriesAPI)(nil).UpdateTransactionCategory), ctx, transactionID, categoryID)
+	return &MockCategoriesAPIUpdateTransactionCategoryCall{Call: call}
+}
+
+// MockCategoriesAPIUpdateTransactionCategoryCall wrap *gomock.Call
+type MockCategoriesAPIUpdateTransactionCategoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCategoriesAPIUpdateTransactionCategoryCall) Return(arg0 *http.Response, arg1 error) *MockCategoriesAPIUpdateTransactionCategoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCategoriesAPIUpdateTransactionCategoryCall) Do(f func(context.Context, string, string) (*http.Response, error)) *MockCategoriesAPIUpdateTransactionCategoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCategoriesAPIUpdateTransactionCategoryCall) DoAndReturn(f func(context.Context, string, string) (*http.Response, error)) *MockCategoriesAPIUpdateTransactionCategoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockWebhooksAPI is a mock of WebhooksAPI interface.
+type MockWebhooksAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhooksAPIMockRecorder is the mock recorder for MockWebhooksAPI.
+type MockWebhooksAPIMockRecorder struct {
+	mock *MockWebhooksAPI
+}
+
+// NewMockWebhooksAPI creates a new mock instance.
+func NewMockWebhooksAPI(ctrl *gomock.Controller) *MockWebhooksAPI {
+	mock := &MockWebhooksAPI{ctrl: ctrl}
+	mock.recorder = &MockWebhooksAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooksAPI) EXPECT() *MockWebhooksAPIMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockWebhooksAPI) All(ctx context.Context, opts *up.ListOptions) iter.Seq2[up.Webhook, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx, opts)
+	ret0, _ := ret[0].(iter.Seq2[up.Webhook, error])
+	return ret0
+}
+
+// All indicates an expected call of All.
+func (mr *MockWebhooksAPIMockRecorder) All(ctx, opts any) *MockWebhooksAPIAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockWebhooksAPI)(nil).All), ctx, opts)
+	return &MockWebhooksAPIAllCall{Call: call}
+}
+
+// MockWebhooksAPIAllCall wrap *gomock.Call
+type MockWebhooksAPIAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIAllCall) Return(arg0 iter.Seq2[up.Webhook, error]) *MockWebhooksAPIAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIAllCall) Do(f func(context.Context, *up.ListOptions) iter.Seq2[up.Webhook, error]) *MockWebhooksAPIAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIAllCall) DoAndReturn(f func(context.Context, *up.ListOptions) iter.Seq2[up.Webhook, error]) *MockWebhooksAPIAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AllLogs mocks base method.
+func (m *MockWebhooksAPI) AllLogs(ctx context.Context, webhookID string, opts *up.ListOptions) iter.Seq2[up.WebhookDeliveryLog, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllLogs", ctx, webhookID, opts)
+	ret0, _ := ret[0].(iter.Seq2[up.WebhookDeliveryLog, error])
+	return ret0
+}
+
+// AllLogs indicates an expected call of AllLogs.
+func (mr *MockWebhooksAPIMockRecorder) AllLogs(ctx, webhookID, opts any) *MockWebhooksAPIAllLogsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllLogs", reflect.TypeOf((*MockWebhooksAPI)(nil).AllLogs), ctx, webhookID, opts)
+	return &MockWebhooksAPIAllLogsCall{Call: call}
+}
+
+// MockWebhooksAPIAllLogsCall wrap *gomock.Call
+type MockWebhooksAPIAllLogsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIAllLogsCall) Return(arg0 iter.Seq2[up.WebhookDeliveryLog, error]) *MockWebhooksAPIAllLogsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIAllLogsCall) Do(f func(context.Context, string, *up.ListOptions) iter.Seq2[up.WebhookDeliveryLog, error]) *MockWebhooksAPIAllLogsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIAllLogsCall) DoAndReturn(f func(context.Context, string, *up.ListOptions) iter.Seq2[up.WebhookDeliveryLog, error]) *MockWebhooksAPIAllLogsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Backfill mocks base method.
+func (m *MockWebhooksAPI) Backfill(ctx context.Context, webhookID string, handle up.WebhookEventFunc, opts *up.BackfillOptions) (*up.BackfillResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", ctx, webhookID, handle, opts)
+	ret0, _ := ret[0].(*up.BackfillResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockWebhooksAPIMockRecorder) Backfill(ctx, webhookID, handle, opts any) *MockWebhooksAPIBackfillCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockWebhooksAPI)(nil).Backfill), ctx, webhookID, handle, opts)
+	return &MockWebhooksAPIBackfillCall{Call: call}
+}
+
+// MockWebhooksAPIBackfillCall wrap *gomock.Call
+type MockWebhooksAPIBackfillCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIBackfillCall) Return(arg0 *up.BackfillResult, arg1 error) *MockWebhooksAPIBackfillCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIBackfillCall) Do(f func(context.Context, string, up.WebhookEventFunc, *up.BackfillOptions) (*up.BackfillResult, error)) *MockWebhooksAPIBackfillCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIBackfillCall) DoAndReturn(f func(context.Context, string, up.WebhookEventFunc, *up.BackfillOptions) (*up.BackfillResult, error)) *MockWebhooksAPIBackfillCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockWebhooksAPI) Create(ctx context.Context, url string, description *string) (*up.Webhook, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, url, description)
+	ret0, _ := ret[0].(*up.Webhook)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhooksAPIMockRecorder) Create(ctx, url, description any) *MockWebhooksAPICreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhooksAPI)(nil).Create), ctx, url, description)
+	return &MockWebhooksAPICreateCall{Call: call}
+}
+
+// MockWebhooksAPICreateCall wrap *gomock.Call
+type MockWebhooksAPICreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPICreateCall) Return(arg0 *up.Webhook, arg1 *http.Response, arg2 error) *MockWebhooksAPICreateCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPICreateCall) Do(f func(context.Context, string, *string) (*up.Webhook, *http.Response, error)) *MockWebhooksAPICreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPICreateCall) DoAndReturn(f func(context.Context, string, *string) (*up.Webhook, *http.Response, error)) *MockWebhooksAPICreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockWebhooksAPI) Delete(ctx context.Context, webhookID string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, webhookID)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhooksAPIMockRecorder) Delete(ctx, webhookID any) *MockWebhooksAPIDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhooksAPI)(nil).Delete), ctx, webhookID)
+	return &MockWebhooksAPIDeleteCall{Call: call}
+}
+
+// MockWebhooksAPIDeleteCall wrap *gomock.Call
+type MockWebhooksAPIDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIDeleteCall) Return(arg0 *http.Response, arg1 error) *MockWebhooksAPIDeleteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIDeleteCall) Do(f func(context.Context, string) (*http.Response, error)) *MockWebhooksAPIDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIDeleteCall) DoAndReturn(f func(context.Context, string) (*http.Response, error)) *MockWebhooksAPIDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockWebhooksAPI) Get(ctx context.Context, webhookID string) (*up.Webhook, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, webhookID)
+	ret0, _ := ret[0].(*up.Webhook)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhooksAPIMockRecorder) Get(ctx, webhookID any) *MockWebhooksAPIGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhooksAPI)(nil).Get), ctx, webhookID)
+	return &MockWebhooksAPIGetCall{Call: call}
+}
+
+// MockWebhooksAPIGetCall wrap *gomock.Call
+type MockWebhooksAPIGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIGetCall) Return(arg0 *up.Webhook, arg1 *http.Response, arg2 error) *MockWebhooksAPIGetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIGetCall) Do(f func(context.Context, string) (*up.Webhook, *http.Response, error)) *MockWebhooksAPIGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIGetCall) DoAndReturn(f func(context.Context, string) (*up.Webhook, *http.Response, error)) *MockWebhooksAPIGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Health mocks base method.
+func (m *MockWebhooksAPI) Health(ctx context.Context, webhookID string, since time.Time) (*up.WebhookHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", ctx, webhookID, since)
+	ret0, _ := ret[0].(*up.WebhookHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockWebhooksAPIMockRecorder) Health(ctx, webhookID, since any) *MockWebhooksAPIHealthCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockWebhooksAPI)(nil).Health), ctx, webhookID, since)
+	return &MockWebhooksAPIHealthCall{Call: call}
+}
+
+// MockWebhooksAPIHealthCall wrap *gomock.Call
+type MockWebhooksAPIHealthCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIHealthCall) Return(arg0 *up.WebhookHealth, arg1 error) *MockWebhooksAPIHealthCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIHealthCall) Do(f func(context.Context, string, time.Time) (*up.WebhookHealth, error)) *MockWebhooksAPIHealthCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIHealthCall) DoAndReturn(f func(context.Context, string, time.Time) (*up.WebhookHealth, error)) *MockWebhooksAPIHealthCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HealthAll mocks base method.
+func (m *MockWebhooksAPI) HealthAll(ctx context.Context, since time.Time) ([]*up.WebhookHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthAll", ctx, since)
+	ret0, _ := ret[0].([]*up.WebhookHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthAll indicates an expected call of HealthAll.
+func (mr *MockWebhooksAPIMockRecorder) HealthAll(ctx, since any) *MockWebhooksAPIHealthAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthAll", reflect.TypeOf((*MockWebhooksAPI)(nil).HealthAll), ctx, since)
+	return &MockWebhooksAPIHealthAllCall{Call: call}
+}
+
+// MockWebhooksAPIHealthAllCall wrap *gomock.Call
+type MockWebhooksAPIHealthAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIHealthAllCall) Return(arg0 []*up.WebhookHealth, arg1 error) *MockWebhooksAPIHealthAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIHealthAllCall) Do(f func(context.Context, time.Time) ([]*up.WebhookHealth, error)) *MockWebhooksAPIHealthAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIHealthAllCall) DoAndReturn(f func(context.Context, time.Time) ([]*up.WebhookHealth, error)) *MockWebhooksAPIHealthAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockWebhooksAPI) List(ctx context.Context, opts *up.ListOptions) (*up.WebhookListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].(*up.WebhookListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhooksAPIMockRecorder) List(ctx, opts any) *MockWebhooksAPIListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhooksAPI)(nil).List), ctx, opts)
+	return &MockWebhooksAPIListCall{Call: call}
+}
+
+// MockWebhooksAPIListCall wrap *gomock.Call
+type MockWebhooksAPIListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIListCall) Return(arg0 *up.WebhookListResponse, arg1 *http.Response, arg2 error) *MockWebhooksAPIListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIListCall) Do(f func(context.Context, *up.ListOptions) (*up.WebhookListResponse, *http.Response, error)) *MockWebhooksAPIListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIListCall) DoAndReturn(f func(context.Context, *up.ListOptions) (*up.WebhookListResponse, *http.Response, error)) *MockWebhooksAPIListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListLogs mocks base method.
+func (m *MockWebhooksAPI) ListLogs(ctx context.Context, webhookID string, opts *up.ListOptions) (*up.WebhookDeliveryLogListResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogs", ctx, webhookID, opts)
+	ret0, _ := ret[0].(*up.WebhookDeliveryLogListResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLogs indicates an expected call of ListLogs.
+func (mr *MockWebhooksAPIMockRecorder) ListLogs(ctx, webhookID, opts any) *MockWebhooksAPIListLogsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogs", reflect.TypeOf((*MockWebhooksAPI)(nil).ListLogs), ctx, webhookID, opts)
+	return &MockWebhooksAPIListLogsCall{Call: call}
+}
+
+// MockWebhooksAPIListLogsCall wrap *gomock.Call
+type MockWebhooksAPIListLogsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIListLogsCall) Return(arg0 *up.WebhookDeliveryLogListResponse, arg1 *http.Response, arg2 error) *MockWebhooksAPIListLogsCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIListLogsCall) Do(f func(context.Context, string, *up.ListOptions) (*up.WebhookDeliveryLogListResponse, *http.Response, error)) *MockWebhooksAPIListLogsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIListLogsCall) DoAndReturn(f func(context.Context, string, *up.ListOptions) (*up.WebhookDeliveryLogListResponse, *http.Response, error)) *MockWebhooksAPIListLogsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListLogsPage mocks base method.
+func (m *MockWebhooksAPI) ListLogsPage(ctx context.Context, webhookID string, opts *up.ListOptions) (*up.Page[up.WebhookDeliveryLog], *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogsPage", ctx, webhookID, opts)
+	ret0, _ := ret[0].(*up.Page[up.WebhookDeliveryLog])
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLogsPage indicates an expected call of ListLogsPage.
+func (mr *MockWebhooksAPIMockRecorder) ListLogsPage(ctx, webhookID, opts any) *MockWebhooksAPIListLogsPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogsPage", reflect.TypeOf((*MockWebhooksAPI)(nil).ListLogsPage), ctx, webhookID, opts)
+	return &MockWebhooksAPIListLogsPageCall{Call: call}
+}
+
+// MockWebhooksAPIListLogsPageCall wrap *gomock.Call
+type MockWebhooksAPIListLogsPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIListLogsPageCall) Return(arg0 *up.Page[up.WebhookDeliveryLog], arg1 *http.Response, arg2 error) *MockWebhooksAPIListLogsPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIListLogsPageCall) Do(f func(context.Context, string, *up.ListOptions) (*up.Page[up.WebhookDeliveryLog], *http.Response, error)) *MockWebhooksAPIListLogsPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIListLogsPageCall) DoAndReturn(f func(context.Context, string, *up.ListOptions) (*up.Page[up.WebhookDeliveryLog], *http.Response, error)) *MockWebhooksAPIListLogsPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPage mocks base method.
+func (m *MockWebhooksAPI) ListPage(ctx context.Context, opts *up.ListOptions) (*up.Page[up.Webhook], *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, opts)
+	ret0, _ := ret[0].(*up.Page[up.Webhook])
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockWebhooksAPIMockRecorder) ListPage(ctx, opts any) *MockWebhooksAPIListPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockWebhooksAPI)(nil).ListPage), ctx, opts)
+	return &MockWebhooksAPIListPageCall{Call: call}
+}
+
+// MockWebhooksAPIListPageCall wrap *gomock.Call
+type MockWebhooksAPIListPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIListPageCall) Return(arg0 *up.Page[up.Webhook], arg1 *http.Response, arg2 error) *MockWebhooksAPIListPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIListPageCall) Do(f func(context.Context, *up.ListOptions) (*up.Page[up.Webhook], *http.Response, error)) *MockWebhooksAPIListPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIListPageCall) DoAndReturn(f func(context.Context, *up.ListOptions) (*up.Page[up.Webhook], *http.Response, error)) *MockWebhooksAPIListPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Ping mocks base method.
+func (m *MockWebhooksAPI) Ping(ctx context.Context, webhookID string) (*up.WebhookEvent, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx, webhookID)
+	ret0, _ := ret[0].(*up.WebhookEvent)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockWebhooksAPIMockRecorder) Ping(ctx, webhookID any) *MockWebhooksAPIPingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockWebhooksAPI)(nil).Ping), ctx, webhookID)
+	return &MockWebhooksAPIPingCall{Call: call}
+}
+
+// MockWebhooksAPIPingCall wrap *gomock.Call
+type MockWebhooksAPIPingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIPingCall) Return(arg0 *up.WebhookEvent, arg1 *http.Response, arg2 error) *MockWebhooksAPIPingCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIPingCall) Do(f func(context.Context, string) (*up.WebhookEvent, *http.Response, error)) *MockWebhooksAPIPingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIPingCall) DoAndReturn(f func(context.Context, string) (*up.WebhookEvent, *http.Response, error)) *MockWebhooksAPIPingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Reconcile mocks base method.
+func (m *MockWebhooksAPI) Reconcile(ctx context.Context, desired []up.WebhookSpec, opts *up.ReconcileOptions) (*up.WebhookPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, desired, opts)
+	ret0, _ := ret[0].(*up.WebhookPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockWebhooksAPIMockRecorder) Reconcile(ctx, desired, opts any) *MockWebhooksAPIReconcileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockWebhooksAPI)(nil).Reconcile), ctx, desired, opts)
+	return &MockWebhooksAPIReconcileCall{Call: call}
+}
+
+// MockWebhooksAPIReconcileCall wrap *gomock.Call
+type MockWebhooksAPIReconcileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhooksAPIReconcileCall) Return(arg0 *up.WebhookPlan, arg1 error) *MockWebhooksAPIReconcileCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhooksAPIReconcileCall) Do(f func(context.Context, []up.WebhookSpec, *up.ReconcileOptions) (*up.WebhookPlan, error)) *MockWebhooksAPIReconcileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhooksAPIReconcileCall) DoAndReturn(f func(context.Context, []up.WebhookSpec, *up.ReconcileOptions) (*up.WebhookPlan, error)) *MockWebhooksAPIReconcileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockUtilityAPI is a mock of UtilityAPI interface.
+type MockUtilityAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUtilityAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockUtilityAPIMockRecorder is the mock recorder for MockUtilityAPI.
+type MockUtilityAPIMockRecorder struct {
+	mock *MockUtilityAPI
+}
+
+// NewMockUtilityAPI creates a new mock instance.
+func NewMockUtilityAPI(ctrl *gomock.Controller) *MockUtilityAPI {
+	mock := &MockUtilityAPI{ctrl: ctrl}
+	mock.recorder = &MockUtilityAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUtilityAPI) EXPECT() *MockUtilityAPIMockRecorder {
+	return m.recorder
+}
+
+// Ping mocks base method.
+func (m *MockUtilityAPI) Ping(ctx context.Context) (*up.PingResponse, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(*up.PingResponse)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockUtilityAPIMockRecorder) Ping(ctx any) *MockUtilityAPIPingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockUtilityAPI)(nil).Ping), ctx)
+	return &MockUtilityAPIPingCall{Call: call}
+}
+
+// MockUtilityAPIPingCall wrap *gomock.Call
+type MockUtilityAPIPingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUtilityAPIPingCall) Return(arg0 *up.PingResponse, arg1 *http.Response, arg2 error) *MockUtilityAPIPingCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUtilityAPIPingCall) Do(f func(context.Context) (*up.PingResponse, *http.Response, error)) *MockUtilityAPIPingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUtilityAPIPingCall) DoAndReturn(f func(context.Context) (*up.PingResponse, *http.Response, error)) *MockUtilityAPIPingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
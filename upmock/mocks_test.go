@@ -0,0 +1,65 @@
+package upmock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jordanst3wart/up-client/up"
+	"github.com/jordanst3wart/up-client/upmock"
+	"go.uber.org/mock/gomock"
+)
+
+// settledTotal is code under test that depends on up.ClientAPI rather than *up.Client
+func settledTotal(ctx context.Context, api *up.ClientAPI, ids []string) (int64, error) {
+	var total int64
+	for _, id := range ids {
+		tx, _, err := api.Transactions.Get(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if tx.Attributes.Status == up.TransactionStatusSettled {
+			total += tx.Attributes.Amount.ValueInBaseUnits
+		}
+	}
+	return total, nil
+}
+
+func mockTransaction(id string, status up.TransactionStatusEnum, cents int64) *up.Transaction {
+	tx := &up.Transaction{Type: "transactions", ID: id}
+	tx.Attributes.Status = status
+	tx.Attributes.Amount = up.NewMoney("AUD", cents).MoneyObject()
+	return tx
+}
+
+func TestMockTransactionsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	txs := upmock.NewMockTransactionsAPI(ctrl)
+	api := &up.ClientAPI{Transactions: txs}
+
+	gomock.InOrder(
+		txs.EXPECT().Get(gomock.Any(), "tx-1").Return(mockTransaction("tx-1", up.TransactionStatusSettled, -450), nil, nil),
+		txs.EXPECT().Get(gomock.Any(), "tx-2").Return(mockTransaction("tx-2", up.TransactionStatusHeld, -3210), nil, nil),
+		txs.EXPECT().Get(gomock.Any(), "tx-3").Return(mockTransaction("tx-3", up.TransactionStatusSettled, -8720), nil, nil),
+	)
+
+	total, err := settledTotal(context.Background(), api, []string{"tx-1", "tx-2", "tx-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != -9170 {
+		t.Errorf("total = %d, want -9170", total)
+	}
+}
+
+func TestMockTransactionsAPIError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	txs := upmock.NewMockTransactionsAPI(ctrl)
+
+	txs.EXPECT().Get(gomock.Any(), "tx-1").Return(nil, nil, up.ErrNotFound).Times(1)
+
+	_, err := settledTotal(context.Background(), &up.ClientAPI{Transactions: txs}, []string{"tx-1", "tx-2"})
+	if !errors.Is(err, up.ErrNotFound) {
+		t.Errorf("settledTotal returned %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,203 @@
+package upsim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// Scenario is a scripted sequence of webhook events
+type Scenario struct {
+	Name  string `json:"name"`
+	Steps []Step `json:"steps"`
+}
+
+// Step sends one event, optionally after a delay, and updates the simulated
+// transaction it refers to
+type Step struct {
+	Event up.WebhookEventTypeEnum `json:"event"`
+	// Delay is waited before the step, e.g. "2s"
+	Delay       string           `json:"delay,omitempty"`
+	Transaction *TransactionSpec `json:"transaction,omitempty"`
+}
+
+// TransactionSpec describes the state of a simulated transaction after a
+// step. Empty fields keep the transaction's previous value.
+type TransactionSpec struct {
+	ID          string                   `json:"id"`
+	Description string                   `json:"description,omitempty"`
+	Amount      string                   `json:"amount,omitempty"`
+	Currency    string                   `json:"currency,omitempty"`
+	Status      up.TransactionStatusEnum `json:"status,omitempty"`
+	AccountID   string                   `json:"accountId,omitempty"`
+}
+
+// StepResult records the outcome of a step
+type StepResult struct {
+	Step       int
+	EventID    string
+	EventType  up.WebhookEventTypeEnum
+	StatusCode int
+}
+
+// LoadScenario decodes a JSON scenario
+func LoadScenario(r io.Reader) (*Scenario, error) {
+	var sc Scenario
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sc); err != nil {
+		return nil, fmt.Errorf("upsim: decoding scenario: %w", err)
+	}
+	return &sc, nil
+}
+
+// Run plays the steps of sc in order. Receiver responses are recorded in the
+// results rather than treated as errors; an error is returned only if a step
+// is invalid or could not be sent.
+func (s *Simulator) Run(ctx context.Context, sc *Scenario) ([]StepResult, error) {
+	var results []StepResult
+	for i, step := range sc.Steps {
+		if step.Delay != "" {
+			d, err := time.ParseDuration(step.Delay)
+			if err != nil {
+				return results, fmt.Errorf("upsim: step %d: invalid delay: %w", i+1, err)
+			}
+			select {
+			case <-time.After(d):
+			case <-ctx.Done():
+				return results, ctx.Err()
+			}
+		}
+
+		var txID string
+		if step.Transaction != nil {
+			txID = step.Transaction.ID
+			if err := s.apply(step.Event, step.Transaction); err != nil {
+				return results, fmt.Errorf("upsim: step %d: %w", i+1, err)
+			}
+		} else if step.Event != up.WebhookEventPing {
+			return results, fmt.Errorf("upsim: step %d: %s requires a transaction", i+1, step.Event)
+		}
+
+		event := s.Event(step.Event, txID)
+		status, err := s.Send(ctx, event)
+		if err != nil {
+			return results, fmt.Errorf("upsim: step %d: %w", i+1, err)
+		}
+		results = append(results, StepResult{Step: i + 1, EventID: event.ID, EventType: step.Event, StatusCode: status})
+	}
+	return results, nil
+}
+
+// apply updates the simulated transaction for an event
+func (s *Simulator) apply(eventType up.WebhookEventTypeEnum, spec *TransactionSpec) error {
+	if spec.ID == "" {
+		return fmt.Errorf("transaction id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if eventType == up.WebhookEventTransactionDeleted {
+		delete(s.transactions, spec.ID)
+		return nil
+	}
+
+	tx, ok := s.transactions[spec.ID]
+	if !ok {
+		tx = newTransaction(spec.ID, s.now())
+		s.transactions[spec.ID] = tx
+	}
+	previous := tx.Attributes.Amount
+	wasHeld := tx.Attributes.Status == up.TransactionStatusHeld
+
+	if spec.Description != "" {
+		tx.Attributes.Description = spec.Description
+		rawText := spec.Description
+		tx.Attributes.RawText = &rawText
+	}
+	if spec.AccountID != "" {
+		tx.Relationships.Account.Data.ID = spec.AccountID
+	}
+	if spec.Amount != "" || spec.Currency != "" {
+		currency := spec.Currency
+		if currency == "" {
+			currency = tx.Attributes.Amount.CurrencyCode
+		}
+		amount := spec.Amount
+		if amount == "" {
+			amount = tx.Attributes.Amount.Value
+		}
+		m, err := up.ParseMoney(currency, amount)
+		if err != nil {
+			return err
+		}
+		tx.Attributes.Amount = m.MoneyObject()
+	}
+
+	status := spec.Status
+	if status == "" && eventType == up.WebhookEventTransactionSettled {
+		status = up.TransactionStatusSettled
+	}
+	if status != "" {
+		tx.Attributes.Status = status
+	}
+
+	if tx.Attributes.Status == up.TransactionStatusSettled && tx.Attributes.SettledAt == nil {
+		settledAt := s.now()
+		tx.Attributes.SettledAt = &settledAt
+		if wasHeld && ok {
+			tx.Attributes.HoldInfo = &up.HoldInfo{Amount: previous}
+		}
+	}
+	return nil
+}
+
+func newTransaction(id string, createdAt time.Time) *up.Transaction {
+	tx := &up.Transaction{
+		Type: "transactions",
+		ID:   id,
+		Attributes: up.TransactionAttributes{
+			Status:          up.TransactionStatusHeld,
+			Description:     "Simulated transaction",
+			IsCategorizable: true,
+			Amount:          up.NewMoney("AUD", 0).MoneyObject(),
+			CreatedAt:       createdAt,
+		},
+	}
+	tx.Relationships.Account.Data = up.AccountData{Type: "accounts", ID: "sim-account"}
+	return tx
+}
+
+// HeldThenSettled returns a scenario where a card purchase is held for one
+// amount and later settles for another, as happens with tips and fuel
+func HeldThenSettled(transactionID, description, heldAmount, settledAmount string) *Scenario {
+	return &Scenario{
+		Name: "held-then-settled",
+		Steps: []Step{
+			{Event: up.WebhookEventTransactionCreated, Transaction: &TransactionSpec{
+				ID: transactionID, Description: description, Amount: heldAmount, Status: up.TransactionStatusHeld,
+			}},
+			{Event: up.WebhookEventTransactionSettled, Delay: "1s", Transaction: &TransactionSpec{
+				ID: transactionID, Amount: settledAmount, Status: up.TransactionStatusSettled,
+			}},
+		},
+	}
+}
+
+// HeldThenDeleted returns a scenario where a held purchase is reversed before it settles
+func HeldThenDeleted(transactionID, description, amount string) *Scenario {
+	return &Scenario{
+		Name: "held-then-deleted",
+		Steps: []Step{
+			{Event: up.WebhookEventTransactionCreated, Transaction: &TransactionSpec{
+				ID: transactionID, Description: description, Amount: amount, Status: up.TransactionStatusHeld,
+			}},
+			{Event: up.WebhookEventTransactionDeleted, Delay: "1s", Transaction: &TransactionSpec{ID: transactionID}},
+		},
+	}
+}
@@ -0,0 +1,161 @@
+// Package upsim simulates Up webhook deliveries for local development. It
+// builds realistic, correctly signed webhook events, posts them to a local
+// receiver and can serve the transactions they refer to so receivers that
+// hydrate events can be exercised without a real Up account.
+package upsim
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// Simulator sends signed webhook events to a receiver
+type Simulator struct {
+	// TargetURL is the receiver's webhook URL
+	TargetURL string
+	// SecretKey signs every payload, as Up does with the webhook's secret key
+	SecretKey string
+	// WebhookID is reported as the webhook the events belong to
+	WebhookID string
+	// HTTPClient sends the events. http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+	// Now returns the time events are stamped with. time.Now is used when nil.
+	Now func() time.Time
+
+	mu           sync.Mutex
+	transactions map[string]*up.Transaction
+}
+
+// New returns a simulator posting events signed with secretKey to targetURL
+func New(targetURL, secretKey string) *Simulator {
+	return &Simulator{
+		TargetURL:    targetURL,
+		SecretKey:    secretKey,
+		WebhookID:    "sim-webhook",
+		transactions: make(map[string]*up.Transaction),
+	}
+}
+
+// Event builds a webhook event of the given type. transactionID is ignored for PING events.
+func (s *Simulator) Event(eventType up.WebhookEventTypeEnum, transactionID string) *up.WebhookEvent {
+	event := &up.WebhookEvent{
+		Type: "webhook-events",
+		ID:   newID(),
+		Attributes: up.WebhookEventAttributes{
+			EventType: eventType,
+			CreatedAt: s.now(),
+		},
+	}
+	event.Relationships.Webhook.Data.Type = "webhooks"
+	event.Relationships.Webhook.Data.ID = s.WebhookID
+
+	if eventType != up.WebhookEventPing && transactionID != "" {
+		rel := &up.WebhookEventRelationship{}
+		rel.Data.Type = "transactions"
+		rel.Data.ID = transactionID
+		if eventType != up.WebhookEventTransactionDeleted {
+			rel.Links = &struct {
+				Related string `json:"related"`
+			}{Related: "https://api.up.com.au/api/v1/transactions/" + transactionID}
+		}
+		event.Relationships.Transaction = rel
+	}
+	return event
+}
+
+// Payload encodes event as Up sends it and returns the body with its signature
+func (s *Simulator) Payload(event *up.WebhookEvent) (body []byte, signature string, err error) {
+	body, err = json.Marshal(&up.WebhookEventResponse{Data: *event})
+	if err != nil {
+		return nil, "", err
+	}
+	return body, hex.EncodeToString(up.SignWebhookBody([]byte(s.SecretKey), body)), nil
+}
+
+// Send posts event to the receiver and returns the status code it responded with
+func (s *Simulator) Send(ctx context.Context, event *up.WebhookEvent) (int, error) {
+	body, signature, err := s.Payload(event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Up Webhooks")
+	req.Header.Set(up.SignatureHeader, signature)
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// Transaction returns a copy of the current state of a simulated
+// transaction, which later steps do not change
+func (s *Simulator) Transaction(id string) (*up.Transaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.transactions[id]
+	if !ok {
+		return nil, false
+	}
+	// apply replaces pointer fields rather than writing through them, so a
+	// shallow copy is safe to read without the lock
+	cp := *tx
+	return &cp, true
+}
+
+// Handler serves GET /transactions/{id} for the simulated transactions, so a
+// client created with up.WithBaseURL pointing at it can hydrate events
+func (s *Simulator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /transactions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		tx, ok := s.Transaction(r.PathValue("id"))
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"errors":[{"status":"404","title":"Not Found","detail":"The transaction does not exist."}]}`)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&up.TransactionGetResponse{Data: *tx})
+	})
+	return mux
+}
+
+func (s *Simulator) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// newID returns a random identifier shaped like the UUIDs Up uses
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	h := hex.EncodeToString(b[:])
+	return strings.Join([]string{h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]}, "-")
+}
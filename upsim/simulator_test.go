@@ -0,0 +1,191 @@
+package upsim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+const testSecret = "s3cret"
+
+// hydratedEvent is what the receiver saw for one event
+type hydratedEvent struct {
+	eventType up.WebhookEventTypeEnum
+	tx        *up.Transaction
+}
+
+// receiver starts a simulator delivering to a webhook handler whose
+// dispatcher hydrates transactions from the simulator through an up.Client.
+// An async dispatcher hydrates while later steps are applied.
+func receiver(t *testing.T, async bool) (*Simulator, func() []hydratedEvent) {
+	t.Helper()
+
+	sim := New("", testSecret)
+	api := httptest.NewServer(sim.Handler())
+	t.Cleanup(api.Close)
+	client, err := up.New("test-token", up.WithBaseURL(api.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu   sync.Mutex
+		seen []hydratedEvent
+	)
+	record := func(ctx context.Context, event *up.WebhookEvent, tx *up.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, hydratedEvent{event.Attributes.EventType, tx})
+		return nil
+	}
+	opts := []up.WebhookDispatcherOption{up.WithHydration(client)}
+	if async {
+		opts = append(opts, up.WithAsync(func(event *up.WebhookEvent, err error) {
+			t.Errorf("handling %s: %v", event.Attributes.EventType, err)
+		}))
+	}
+	d := up.NewWebhookDispatcher(opts...)
+	d.OnTransactionCreated(record)
+	d.OnTransactionSettled(record)
+	d.OnTransactionDeleted(func(ctx context.Context, event *up.WebhookEvent) error {
+		// The transaction is gone, so hydrating it must fail
+		if _, _, err := client.Transactions.Get(ctx, event.TransactionID()); !errors.Is(err, up.ErrNotFound) {
+			t.Errorf("deleted transaction lookup returned %v, want ErrNotFound", err)
+		}
+		return record(ctx, event, nil)
+	})
+
+	hook := httptest.NewServer(up.NewWebhookHandler(testSecret, d.Dispatch))
+	t.Cleanup(hook.Close)
+	sim.TargetURL = hook.URL
+
+	return sim, func() []hydratedEvent {
+		d.Wait()
+		mu.Lock()
+		defer mu.Unlock()
+		return seen
+	}
+}
+
+// withoutDelays removes the delays between the steps of sc
+func withoutDelays(sc *Scenario) *Scenario {
+	for i := range sc.Steps {
+		sc.Steps[i].Delay = ""
+	}
+	return sc
+}
+
+func TestRunHeldThenSettled(t *testing.T) {
+	sim, seen := receiver(t, true)
+
+	results, err := sim.Run(context.Background(), withoutDelays(HeldThenSettled("tx-1", "Shell Coles Express", "-1.00", "-65.20")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.StatusCode != http.StatusOK {
+			t.Errorf("step %d: receiver responded %d", r.Step, r.StatusCode)
+		}
+	}
+
+	events := seen()
+	if len(events) != 2 {
+		t.Fatalf("receiver handled %d events, want 2", len(events))
+	}
+	// The settled event hydrates the final state
+	var settled *up.Transaction
+	for _, e := range events {
+		if e.eventType == up.WebhookEventTransactionSettled {
+			settled = e.tx
+		}
+	}
+	if settled == nil || settled.ID != "tx-1" || settled.Attributes.Status != up.TransactionStatusSettled ||
+		settled.Attributes.Amount.Value != "-65.20" || settled.Attributes.Description != "Shell Coles Express" {
+		t.Fatalf("settled transaction = %+v", settled)
+	}
+	if settled.Attributes.SettledAt == nil || settled.Attributes.HoldInfo == nil || settled.Attributes.HoldInfo.Amount.Value != "-1.00" {
+		t.Errorf("settled transaction has settledAt %v and hold info %+v, want the held amount", settled.Attributes.SettledAt, settled.Attributes.HoldInfo)
+	}
+}
+
+func TestRunHeldThenDeleted(t *testing.T) {
+	// Hydrating the created event must happen before the deletion
+	sim, seen := receiver(t, false)
+
+	if _, err := sim.Run(context.Background(), withoutDelays(HeldThenDeleted("tx-1", "Uber Eats", "-32.10"))); err != nil {
+		t.Fatal(err)
+	}
+	events := seen()
+	if len(events) != 2 {
+		t.Fatalf("receiver handled %d events, want 2", len(events))
+	}
+	if _, ok := sim.Transaction("tx-1"); ok {
+		t.Error("deleted transaction is still served")
+	}
+}
+
+func TestTransactionIsACopy(t *testing.T) {
+	sim := New("", testSecret)
+	if err := sim.apply(up.WebhookEventTransactionCreated, &TransactionSpec{ID: "tx-1", Amount: "-4.50"}); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := sim.Transaction("tx-1")
+
+	if err := sim.apply(up.WebhookEventTransactionSettled, &TransactionSpec{ID: "tx-1", Amount: "-5.00"}); err != nil {
+		t.Fatal(err)
+	}
+	if before.Attributes.Status != up.TransactionStatusHeld || before.Attributes.Amount.Value != "-4.50" {
+		t.Errorf("earlier copy changed to %s %s", before.Attributes.Status, before.Attributes.Amount.Value)
+	}
+	after, _ := sim.Transaction("tx-1")
+	if after.Attributes.Status != up.TransactionStatusSettled || after.Attributes.Amount.Value != "-5.00" {
+		t.Errorf("current state = %s %s", after.Attributes.Status, after.Attributes.Amount.Value)
+	}
+}
+
+func TestRunRejectsInvalidSteps(t *testing.T) {
+	sim := New("http://127.0.0.1:0", testSecret)
+	tests := []struct {
+		scenario string
+		want     string
+	}{
+		{`{"steps":[{"event":"TRANSACTION_CREATED"}]}`, "requires a transaction"},
+		{`{"steps":[{"event":"TRANSACTION_CREATED","transaction":{"id":""}}]}`, "transaction id is required"},
+		{`{"steps":[{"event":"PING","delay":"soon"}]}`, "invalid delay"},
+		{`{"steps":[{"event":"TRANSACTION_CREATED","transaction":{"id":"tx-1","amount":"lots"}}]}`, "step 1"},
+	}
+	for _, tt := range tests {
+		sc, err := LoadScenario(strings.NewReader(tt.scenario))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.scenario, err)
+		}
+		if _, err := sim.Run(context.Background(), sc); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Run returned %v, want %q", tt.scenario, err, tt.want)
+		}
+	}
+
+	if _, err := LoadScenario(strings.NewReader(`{"steps":[],"extra":true}`)); err == nil {
+		t.Error("LoadScenario accepted an unknown field")
+	}
+}
+
+func TestPayloadSignature(t *testing.T) {
+	sim := New("", testSecret)
+	body, signature, err := sim.Payload(sim.Event(up.WebhookEventPing, "ignored"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !up.VerifyWebhookSignature([]byte(testSecret), body, signature) {
+		t.Error("payload signature does not verify")
+	}
+	event, err := up.ParseWebhookEvent(body)
+	if err != nil || event.TransactionID() != "" || event.Relationships.Webhook.Data.ID != "sim-webhook" {
+		t.Errorf("ping event = %+v, %v", event, err)
+	}
+}
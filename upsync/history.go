@@ -0,0 +1,199 @@
+package upsync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// ChangeField names a transaction attribute tracked in the history
+type ChangeField string
+
+const (
+	FieldStatus      ChangeField = "status"
+	FieldAmount      ChangeField = "amount"
+	FieldDescription ChangeField = "description"
+	FieldCategory    ChangeField = "category"
+	// FieldTags values are the sorted tag IDs joined with commas
+	FieldTags ChangeField = "tags"
+	// FieldDeleted changes to the tombstone time when a transaction
+	// disappears from the API, and back to empty if it reappears
+	FieldDeleted ChangeField = "deleted"
+)
+
+// Change is one recorded change to a mirrored transaction
+type Change struct {
+	TransactionID string `json:"transactionId"`
+	// ChangedAt is the start of the sync that saw the change
+	ChangedAt time.Time   `json:"changedAt"`
+	Field     ChangeField `json:"field"`
+	Old       string      `json:"old"`
+	New       string      `json:"new"`
+}
+
+// History returns the recorded changes to a transaction, oldest first
+func (s *Store) History(ctx context.Context, transactionID string) ([]Change, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT changed_at, field, old_value, new_value
+		FROM transaction_history WHERE transaction_id = ? ORDER BY id`, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []Change
+	for rows.Next() {
+		var (
+			changedAt string
+			c         = Change{TransactionID: transactionID}
+			from, to  sql.NullString
+		)
+		if err := rows.Scan(&changedAt, &c.Field, &from, &to); err != nil {
+			return nil, err
+		}
+		c.ChangedAt = parseTime(changedAt)
+		c.Old, c.New = from.String, to.String
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// trackedState holds the tracked attributes of a transaction
+type trackedState struct {
+	status      string
+	amount      string
+	description string
+	category    string
+	tags        string
+}
+
+func stateOf(t *up.Transaction) trackedState {
+	var category string
+	if id := categoryID(t.Relationships.Category); id != nil {
+		category = *id
+	}
+	return trackedState{
+		status:      string(t.Attributes.Status),
+		amount:      t.Attributes.Amount.Value,
+		description: t.Attributes.Description,
+		category:    category,
+		tags:        strings.Join(slices.Sorted(slices.Values(t.Relationships.Tags.TagIDs())), ","),
+	}
+}
+
+// storedState reads the tracked attributes of a mirrored transaction
+func storedState(ctx context.Context, tx *sql.Tx, id string) (trackedState, error) {
+	var (
+		st       trackedState
+		category sql.NullString
+	)
+	err := tx.QueryRowContext(ctx, "SELECT status, amount, description, category_id FROM transactions WHERE id = ?", id).
+		Scan(&st.status, &st.amount, &st.description, &category)
+	if err != nil {
+		return st, err
+	}
+	st.category = category.String
+
+	rows, err := tx.QueryContext(ctx, "SELECT tag_id FROM transaction_tags WHERE transaction_id = ? ORDER BY tag_id", id)
+	if err != nil {
+		return st, err
+	}
+	defer rows.Close()
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return st, err
+		}
+		tags = append(tags, tag)
+	}
+	st.tags = strings.Join(tags, ",")
+	return st, rows.Err()
+}
+
+// recordChanges writes a history row for each tracked attribute that differs
+func recordChanges(ctx context.Context, tx *sql.Tx, id, changedAt string, prev, next trackedState) error {
+	for _, c := range []struct {
+		field    ChangeField
+		from, to string
+	}{
+		{FieldStatus, prev.status, next.status},
+		{FieldAmount, prev.amount, next.amount},
+		{FieldDescription, prev.description, next.description},
+		{FieldCategory, prev.category, next.category},
+		{FieldTags, prev.tags, next.tags},
+	} {
+		if c.from == c.to {
+			continue
+		}
+		if err := recordChange(ctx, tx, id, changedAt, c.field, c.from, c.to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordChange(ctx context.Context, tx *sql.Tx, id, changedAt string, field ChangeField, from, to string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO transaction_history (transaction_id, changed_at, field, old_value, new_value)
+		VALUES (?, ?, ?, ?, ?)`,
+		id, changedAt, field, nullString(from), nullString(to))
+	if err != nil {
+		return fmt.Errorf("upsync: recording change to transaction %s: %w", id, err)
+	}
+	return nil
+}
+
+// tombstone marks mirrored transactions created after since that the API no
+// longer lists as deleted, and returns how many were marked. It must only be
+// called with the complete listing for the window.
+func tombstone(ctx context.Context, tx *sql.Tx, since time.Time, listed map[string]bool, deletedAt string) (int, error) {
+	// Rows created exactly at since are left alone in case the API treats
+	// the filter as exclusive. A full sync compares against every row.
+	var after string
+	if !since.IsZero() {
+		after = formatTime(since)
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM transactions WHERE deleted_at IS NULL AND created_at > ?", after)
+	if err != nil {
+		return 0, err
+	}
+	var missing []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if !listed[id] {
+			missing = append(missing, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, id := range missing {
+		if _, err := tx.ExecContext(ctx, "UPDATE transactions SET deleted_at = ? WHERE id = ?", deletedAt, id); err != nil {
+			return 0, fmt.Errorf("upsync: marking transaction %s deleted: %w", id, err)
+		}
+		if err := recordChange(ctx, tx, id, deletedAt, FieldDeleted, "", deletedAt); err != nil {
+			return 0, err
+		}
+	}
+	return len(missing), nil
+}
+
+// nullString stores the empty string as NULL
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
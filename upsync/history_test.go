@@ -0,0 +1,163 @@
+package upsync
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+	"github.com/jordanst3wart/up-client/uptest"
+)
+
+func deletedAt(t *testing.T, store *Store, id string) sql.NullString {
+	t.Helper()
+	var v sql.NullString
+	if err := store.DB().QueryRow("SELECT deleted_at FROM transactions WHERE id = ?", id).Scan(&v); err != nil {
+		t.Fatalf("reading %s: %v", id, err)
+	}
+	return v
+}
+
+func TestSyncTombstone(t *testing.T) {
+	ctx := context.Background()
+	srv, store, syncer := newMirror(t)
+	mustSync(t, syncer)
+
+	// The next window starts at tx-4, which is left alone as it sits on the
+	// boundary. tx-1 is outside the window.
+	for _, id := range []string{"tx-1", "tx-4", "tx-5"} {
+		srv.DeleteTransaction(id)
+	}
+	cp := mustSync(t, syncer)
+	if want := fixtureBase.Add(3 * 24 * time.Hour); !cp.Since.Equal(want) {
+		t.Fatalf("since = %s, want %s", cp.Since, want)
+	}
+	if cp.Deleted != 1 {
+		t.Errorf("deleted = %d, want 1", cp.Deleted)
+	}
+	for id, want := range map[string]bool{"tx-1": false, "tx-4": false, "tx-5": true} {
+		if got := deletedAt(t, store, id).Valid; got != want {
+			t.Errorf("%s tombstoned = %t, want %t", id, got, want)
+		}
+	}
+	if want := fixtureBase.Add(3 * 24 * time.Hour); !cp.Newest.Equal(want) {
+		t.Errorf("newest = %s, want %s, ignoring the tombstoned tx-5", cp.Newest, want)
+	}
+
+	changes, err := store.History(ctx, "tx-5")
+	if err != nil || len(changes) != 1 {
+		t.Fatalf("history = %+v, %v", changes, err)
+	}
+	if c := changes[0]; c.Field != FieldDeleted || c.Old != "" || c.New != deletedAt(t, store, "tx-5").String {
+		t.Errorf("change = %+v, want deleted set to the tombstone time", c)
+	}
+
+	// A full sync compares every mirrored transaction
+	syncer.full = true
+	cp = mustSync(t, syncer)
+	if cp.Deleted != 2 || !deletedAt(t, store, "tx-1").Valid || !deletedAt(t, store, "tx-4").Valid {
+		t.Errorf("full sync deleted %d, want tx-1 and tx-4", cp.Deleted)
+	}
+}
+
+func TestSyncTombstonesOldestHeld(t *testing.T) {
+	srv, store, syncer := newMirror(t)
+	srv.AddTransaction(uptest.NewTransaction("tx-6", "acc-spending", "Bakery", "-6.00",
+		up.TransactionStatusSettled, fixtureBase.Add(10*24*time.Hour)))
+	mustSync(t, syncer)
+
+	// The window is widened back to held tx-5, which then disappears
+	srv.DeleteTransaction("tx-5")
+	cp := mustSync(t, syncer)
+	if want := fixtureBase.Add(4*24*time.Hour - heldMargin); !cp.Since.Equal(want) {
+		t.Errorf("since = %s, want just before held tx-5 (%s)", cp.Since, want)
+	}
+	if cp.Deleted != 1 || !deletedAt(t, store, "tx-5").Valid {
+		t.Fatalf("deleted = %d, want held tx-5 tombstoned", cp.Deleted)
+	}
+
+	// With nothing held the window is no longer pinned to tx-5
+	cp = mustSync(t, syncer)
+	if want := fixtureBase.Add(9 * 24 * time.Hour); !cp.Since.Equal(want) {
+		t.Errorf("since = %s, want %s", cp.Since, want)
+	}
+	if cp.Deleted != 0 {
+		t.Errorf("deleted = %d on the next run, want 0", cp.Deleted)
+	}
+}
+
+func TestSyncRestore(t *testing.T) {
+	ctx := context.Background()
+	srv, store, syncer := newMirror(t)
+	mustSync(t, syncer)
+
+	tx5, _ := srv.Transaction("tx-5")
+	srv.DeleteTransaction("tx-5")
+	if cp := mustSync(t, syncer); cp.Deleted != 1 {
+		t.Fatalf("deleted = %d, want 1", cp.Deleted)
+	}
+	tombstoned := deletedAt(t, store, "tx-5").String
+
+	srv.AddTransaction(tx5)
+	cp := mustSync(t, syncer)
+	if cp.Updated != 1 || cp.Inserted != 0 || cp.Deleted != 0 {
+		t.Errorf("checkpoint = %+v, want tx-5 updated", cp)
+	}
+	if v := deletedAt(t, store, "tx-5"); v.Valid {
+		t.Errorf("deleted_at = %q after the transaction reappeared", v.String)
+	}
+
+	changes, err := store.History(ctx, "tx-5")
+	if err != nil || len(changes) != 2 {
+		t.Fatalf("history = %+v, %v", changes, err)
+	}
+	if c := changes[1]; c.Field != FieldDeleted || c.Old != tombstoned || c.New != "" {
+		t.Errorf("change = %+v, want deleted cleared from %s", c, tombstoned)
+	}
+}
+
+func TestSyncHistory(t *testing.T) {
+	ctx := context.Background()
+	srv, store, syncer := newMirror(t)
+	mustSync(t, syncer)
+
+	amount, err := up.ParseMoney("AUD", "-12.80")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.UpdateTransaction("tx-5", func(tx *up.Transaction) {
+		tx.Attributes.Amount = amount.MoneyObject()
+		tx.Attributes.Description = "Uber Eats Sydney"
+		uptest.SetCategory(tx, "groceries", "home")
+		tx.Relationships.Tags.Data = []up.TagInputResource{{Type: "tags", ID: "Work"}, {Type: "tags", ID: "Holiday"}}
+	})
+	if cp := mustSync(t, syncer); cp.Updated != 1 || cp.Settled != 0 {
+		t.Errorf("checkpoint = %+v, want tx-5 updated", cp)
+	}
+
+	changes, err := store.History(ctx, "tx-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{
+		{Field: FieldAmount, Old: "-32.10", New: "-12.80"},
+		{Field: FieldDescription, Old: "Uber Eats", New: "Uber Eats Sydney"},
+		{Field: FieldCategory, Old: "restaurants-and-cafes", New: "groceries"},
+		{Field: FieldTags, Old: "", New: "Holiday,Work"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("history = %+v, want %d changes", changes, len(want))
+	}
+	for i, c := range changes {
+		if c.Field != want[i].Field || c.Old != want[i].Old || c.New != want[i].New || c.TransactionID != "tx-5" || c.ChangedAt.IsZero() {
+			t.Errorf("change %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+
+	// Unchanged transactions add no history
+	mustSync(t, syncer)
+	if again, err := store.History(ctx, "tx-5"); err != nil || len(again) != len(want) {
+		t.Errorf("history after an unchanged sync = %d, %v, want %d", len(again), err, len(want))
+	}
+}
@@ -0,0 +1,101 @@
+package upsync
+
+// migrations create and evolve the schema. migrations[i] moves the database
+// from user_version i to i+1; never edit one that has shipped, append a new one.
+//
+// Times are UTC text in timeLayout and amounts are kept both as the API's
+// decimal string and in base units (cents) for arithmetic. raw holds the
+// resource as returned by the API. Transactions that disappear from the API
+// keep their row with deleted_at set, so current data is WHERE deleted_at IS NULL.
+var migrations = []string{
+	`
+	CREATE TABLE accounts (
+		id                 TEXT PRIMARY KEY,
+		display_name       TEXT NOT NULL,
+		account_type       TEXT NOT NULL,
+		ownership_type     TEXT NOT NULL,
+		balance            TEXT NOT NULL,
+		balance_base_units INTEGER NOT NULL,
+		currency           TEXT NOT NULL,
+		created_at         TEXT NOT NULL,
+		raw                TEXT NOT NULL,
+		synced_at          TEXT NOT NULL
+	);
+
+	CREATE TABLE categories (
+		id        TEXT PRIMARY KEY,
+		name      TEXT NOT NULL,
+		parent_id TEXT REFERENCES categories (id) DEFERRABLE INITIALLY DEFERRED,
+		raw       TEXT NOT NULL,
+		synced_at TEXT NOT NULL
+	);
+
+	CREATE TABLE tags (
+		id        TEXT PRIMARY KEY,
+		synced_at TEXT NOT NULL
+	);
+
+	CREATE TABLE transactions (
+		id                  TEXT PRIMARY KEY,
+		account_id          TEXT NOT NULL,
+		transfer_account_id TEXT,
+		status              TEXT NOT NULL,
+		description         TEXT NOT NULL,
+		raw_text            TEXT,
+		message             TEXT,
+		amount              TEXT NOT NULL,
+		amount_base_units   INTEGER NOT NULL,
+		currency            TEXT NOT NULL,
+		foreign_amount      TEXT,
+		foreign_currency    TEXT,
+		category_id         TEXT,
+		parent_category_id  TEXT,
+		created_at          TEXT NOT NULL,
+		settled_at          TEXT,
+		raw                 TEXT NOT NULL,
+		first_seen_at       TEXT NOT NULL,
+		synced_at           TEXT NOT NULL
+	);
+	CREATE INDEX transactions_created_at ON transactions (created_at);
+	CREATE INDEX transactions_account_id ON transactions (account_id, created_at);
+	CREATE INDEX transactions_status ON transactions (status);
+
+	CREATE TABLE transaction_tags (
+		transaction_id TEXT NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+		tag_id         TEXT NOT NULL,
+		PRIMARY KEY (transaction_id, tag_id)
+	);
+	CREATE INDEX transaction_tags_tag_id ON transaction_tags (tag_id);
+
+	CREATE TABLE sync_checkpoints (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		started_at  TEXT NOT NULL,
+		finished_at TEXT,
+		since       TEXT,
+		newest      TEXT,
+		accounts    INTEGER NOT NULL DEFAULT 0,
+		categories  INTEGER NOT NULL DEFAULT 0,
+		tags        INTEGER NOT NULL DEFAULT 0,
+		fetched     INTEGER NOT NULL DEFAULT 0,
+		inserted    INTEGER NOT NULL DEFAULT 0,
+		updated     INTEGER NOT NULL DEFAULT 0,
+		settled     INTEGER NOT NULL DEFAULT 0,
+		error       TEXT
+	);
+	`,
+	`
+	ALTER TABLE transactions ADD COLUMN deleted_at TEXT;
+
+	CREATE TABLE transaction_history (
+		id             INTEGER PRIMARY KEY AUTOINCREMENT,
+		transaction_id TEXT NOT NULL,
+		changed_at     TEXT NOT NULL,
+		field          TEXT NOT NULL,
+		old_value      TEXT,
+		new_value      TEXT
+	);
+	CREATE INDEX transaction_history_transaction_id ON transaction_history (transaction_id, id);
+
+	ALTER TABLE sync_checkpoints ADD COLUMN deleted INTEGER NOT NULL DEFAULT 0;
+	`,
+}
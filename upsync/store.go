@@ -0,0 +1,210 @@
+// Package upsync mirrors Up accounts, transactions, categories and tags into
+// a local SQLite database and keeps it current with incremental syncs.
+package upsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// timeLayout stores times in UTC with a fixed width so they sort as text
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// Store is a local SQLite mirror of an Up account holder's data
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the database at path and brings its schema up to date
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("upsync: %w", err)
+	}
+
+	// Pragmas are given in the DSN so every pooled connection gets them
+	q := url.Values{}
+	for _, p := range []string{"foreign_keys(1)", "journal_mode(WAL)", "busy_timeout(5000)"} {
+		q.Add("_pragma", p)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?"+q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("upsync: opening %s: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// DB returns the underlying database for ad hoc queries. See schema.go for the tables.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migrate applies the migrations newer than the database's user_version
+func (s *Store) migrate(ctx context.Context) error {
+	var version int
+	if err := s.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("upsync: reading schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("upsync: database schema version %d is newer than this program supports (%d)", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("upsync: migrating schema to version %d: %w", i+1, err)
+		}
+		// PRAGMA does not accept bound parameters
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checkpoint records one sync run
+type Checkpoint struct {
+	ID         int64     `json:"id"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// Since is the filter[since] the run listed transactions from, zero for a full sync
+	Since time.Time `json:"since"`
+	// Newest is the creation time of the newest transaction in the mirror after the run
+	Newest time.Time `json:"newest"`
+
+	Accounts   int `json:"accounts"`
+	Categories int `json:"categories"`
+	Tags       int `json:"tags"`
+	// Fetched counts transactions returned by the API, of which Inserted
+	// were new, Updated had changed and Settled moved from HELD to SETTLED.
+	// Deleted counts mirrored transactions no longer returned.
+	Fetched  int `json:"fetched"`
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+	Settled  int `json:"settled"`
+	Deleted  int `json:"deleted"`
+
+	// Err is the error that stopped the run, empty if it succeeded
+	Err string `json:"error,omitempty"`
+}
+
+// LastCheckpoint returns the most recent successful sync, or nil if there has been none
+func (s *Store) LastCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	cps, err := s.queryCheckpoints(ctx, "WHERE error IS NULL ORDER BY id DESC LIMIT 1")
+	if err != nil || len(cps) == 0 {
+		return nil, err
+	}
+	return cps[0], nil
+}
+
+// Checkpoints returns up to limit sync runs, newest first, including failed ones
+func (s *Store) Checkpoints(ctx context.Context, limit int) ([]*Checkpoint, error) {
+	return s.queryCheckpoints(ctx, "ORDER BY id DESC LIMIT ?", limit)
+}
+
+func (s *Store) queryCheckpoints(ctx context.Context, clause string, args ...any) ([]*Checkpoint, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, started_at, finished_at, since, newest, accounts, categories, tags,
+			fetched, inserted, updated, settled, deleted, error
+		FROM sync_checkpoints `+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cps []*Checkpoint
+	for rows.Next() {
+		var (
+			cp                               Checkpoint
+			started                          string
+			finished, since, newest, errText sql.NullString
+		)
+		err := rows.Scan(&cp.ID, &started, &finished, &since, &newest, &cp.Accounts, &cp.Categories, &cp.Tags,
+			&cp.Fetched, &cp.Inserted, &cp.Updated, &cp.Settled, &cp.Deleted, &errText)
+		if err != nil {
+			return nil, err
+		}
+		cp.StartedAt = parseTime(started)
+		cp.FinishedAt = parseTime(finished.String)
+		cp.Since = parseTime(since.String)
+		cp.Newest = parseTime(newest.String)
+		cp.Err = errText.String
+		cps = append(cps, &cp)
+	}
+	return cps, rows.Err()
+}
+
+// execer is implemented by *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (s *Store) saveCheckpoint(ctx context.Context, db execer, cp *Checkpoint) error {
+	var errText *string
+	if cp.Err != "" {
+		errText = &cp.Err
+	}
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO sync_checkpoints (started_at, finished_at, since, newest, accounts, categories, tags,
+			fetched, inserted, updated, settled, deleted, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		formatTime(cp.StartedAt), nullTime(cp.FinishedAt), nullTime(cp.Since), nullTime(cp.Newest),
+		cp.Accounts, cp.Categories, cp.Tags, cp.Fetched, cp.Inserted, cp.Updated, cp.Settled, cp.Deleted, errText)
+	if err != nil {
+		return fmt.Errorf("upsync: saving checkpoint: %w", err)
+	}
+	cp.ID, _ = res.LastInsertId()
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	s := formatTime(t)
+	return &s
+}
+
+func parseTime(s string) time.Time {
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// errNoRows reports whether err means a lookup found nothing
+func errNoRows(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
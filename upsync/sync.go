@@ -0,0 +1,400 @@
+package upsync
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// DefaultOverlap is how far before the newest mirrored transaction an
+// incremental sync starts listing, to pick up transactions that reached the
+// API late or changed soon after they were created
+const DefaultOverlap = 72 * time.Hour
+
+// heldMargin is how far before the oldest held transaction a widened sync starts
+const heldMargin = time.Second
+
+// Options specifies the optional parameters for a Syncer
+type Options struct {
+	// Overlap defaults to DefaultOverlap
+	Overlap time.Duration
+	// Full lists every transaction instead of syncing incrementally
+	Full bool
+	// PageSize is the number of resources requested per page, 100 by default
+	PageSize int
+	// Now returns the current time, time.Now by default
+	Now func() time.Time
+}
+
+// Syncer copies data from the API into a Store
+type Syncer struct {
+	api      *up.ClientAPI
+	store    *Store
+	overlap  time.Duration
+	full     bool
+	pageSize int
+	now      func() time.Time
+}
+
+// NewSyncer returns a syncer that reads from api, usually client.API(), and
+// writes to store
+func NewSyncer(api *up.ClientAPI, store *Store, opts *Options) *Syncer {
+	if opts == nil {
+		opts = &Options{}
+	}
+	s := &Syncer{
+		api:      api,
+		store:    store,
+		overlap:  opts.Overlap,
+		full:     opts.Full,
+		pageSize: opts.PageSize,
+		now:      opts.Now,
+	}
+	if s.overlap <= 0 {
+		s.overlap = DefaultOverlap
+	}
+	if s.pageSize <= 0 {
+		s.pageSize = 100
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s
+}
+
+// Sync fetches accounts, categories and tags in full, and transactions
+// created since the previous sync less the overlap window. The window is
+// widened to include every transaction still HELD in the mirror, so held
+// rows are updated when they settle. Changes to a transaction's status,
+// amount, description, category or tags are recorded in its history, and
+// mirrored transactions in the window that the API no longer lists are
+// kept with deleted_at set. Nothing is written unless every request
+// succeeds; the run is recorded as a checkpoint either way.
+func (s *Syncer) Sync(ctx context.Context) (*Checkpoint, error) {
+	cp := &Checkpoint{StartedAt: s.now()}
+	err := s.sync(ctx, cp)
+	if err != nil {
+		cp.Err = err.Error()
+		cp.FinishedAt = s.now()
+		// Record the failure even if ctx was cancelled
+		if saveErr := s.store.saveCheckpoint(context.WithoutCancel(ctx), s.store.db, cp); saveErr != nil {
+			return cp, fmt.Errorf("%w (%v)", err, saveErr)
+		}
+	}
+	return cp, err
+}
+
+// fetched holds everything read from the API in one run
+type fetched struct {
+	accounts     []up.Account
+	categories   []up.Category
+	tags         []up.Tag
+	transactions []up.Transaction
+}
+
+func (s *Syncer) sync(ctx context.Context, cp *Checkpoint) error {
+	last, err := s.store.LastCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	cp.Since, err = s.since(ctx, last)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.fetch(ctx, cp.Since)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	syncedAt := formatTime(cp.StartedAt)
+	if err := s.writeAccounts(ctx, tx, data.accounts, syncedAt); err != nil {
+		return err
+	}
+	if err := s.writeCategories(ctx, tx, data.categories, syncedAt); err != nil {
+		return err
+	}
+	if err := s.writeTags(ctx, tx, data.tags, syncedAt); err != nil {
+		return err
+	}
+	cp.Accounts, cp.Categories, cp.Tags = len(data.accounts), len(data.categories), len(data.tags)
+
+	cp.Fetched = len(data.transactions)
+	listed := make(map[string]bool, len(data.transactions))
+	for i := range data.transactions {
+		if err := s.writeTransaction(ctx, tx, &data.transactions[i], syncedAt, cp); err != nil {
+			return err
+		}
+		listed[data.transactions[i].ID] = true
+	}
+	if cp.Deleted, err = tombstone(ctx, tx, cp.Since, listed, syncedAt); err != nil {
+		return err
+	}
+
+	var newest sql.NullString
+	if err := tx.QueryRowContext(ctx, "SELECT MAX(created_at) FROM transactions WHERE deleted_at IS NULL").Scan(&newest); err != nil {
+		return err
+	}
+	cp.Newest = parseTime(newest.String)
+	cp.FinishedAt = s.now()
+
+	if err := s.store.saveCheckpoint(ctx, tx, cp); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// since returns where an incremental sync starts, or the zero time for a full sync
+func (s *Syncer) since(ctx context.Context, last *Checkpoint) (time.Time, error) {
+	if s.full || last == nil || last.Newest.IsZero() {
+		return time.Time{}, nil
+	}
+	since := last.Newest.Add(-s.overlap)
+
+	var oldestHeld sql.NullString
+	err := s.store.db.QueryRowContext(ctx, "SELECT MIN(created_at) FROM transactions WHERE status = ? AND deleted_at IS NULL",
+		up.TransactionStatusHeld).Scan(&oldestHeld)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Start just before the held row, so it is strictly inside the window
+	// that tombstone checks, whether or not the API's filter is inclusive
+	if held := parseTime(oldestHeld.String); !held.IsZero() && !held.After(since) {
+		since = held.Add(-heldMargin)
+	}
+	return since, nil
+}
+
+func (s *Syncer) fetch(ctx context.Context, since time.Time) (*fetched, error) {
+	var (
+		data fetched
+		page = up.ListOptions{PageSize: s.pageSize}
+	)
+
+	for acc, err := range s.api.Accounts.All(ctx, &up.ListAccountsOptions{ListOptions: page}) {
+		if err != nil {
+			return nil, fmt.Errorf("upsync: listing accounts: %w", err)
+		}
+		data.accounts = append(data.accounts, acc)
+	}
+
+	categories, _, err := s.api.Categories.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("upsync: listing categories: %w", err)
+	}
+	data.categories = categories.Data
+
+	for tag, err := range s.api.Tags.All(ctx, &page) {
+		if err != nil {
+			return nil, fmt.Errorf("upsync: listing tags: %w", err)
+		}
+		data.tags = append(data.tags, tag)
+	}
+
+	opts := &up.ListTransactionsOptions{ListOptions: page}
+	if !since.IsZero() {
+		opts.Since = &since
+	}
+	for t, err := range s.api.Transactions.All(ctx, opts) {
+		if err != nil {
+			return nil, fmt.Errorf("upsync: listing transactions: %w", err)
+		}
+		data.transactions = append(data.transactions, t)
+	}
+
+	return &data, nil
+}
+
+func (s *Syncer) writeAccounts(ctx context.Context, tx *sql.Tx, accounts []up.Account, syncedAt string) error {
+	for _, acc := range accounts {
+		raw, err := json.Marshal(acc)
+		if err != nil {
+			return err
+		}
+		balance := acc.Attributes.Balance
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO accounts (id, display_name, account_type, ownership_type, balance, balance_base_units,
+				currency, created_at, raw, synced_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				display_name = excluded.display_name,
+				account_type = excluded.account_type,
+				ownership_type = excluded.ownership_type,
+				balance = excluded.balance,
+				balance_base_units = excluded.balance_base_units,
+				currency = excluded.currency,
+				created_at = excluded.created_at,
+				raw = excluded.raw,
+				synced_at = excluded.synced_at`,
+			acc.ID, acc.Attributes.DisplayName, acc.Attributes.AccountType, acc.Attributes.OwnershipType,
+			balance.Value, balance.ValueInBaseUnits, balance.CurrencyCode, accountCreatedAt(acc), string(raw), syncedAt)
+		if err != nil {
+			return fmt.Errorf("upsync: saving account %s: %w", acc.ID, err)
+		}
+	}
+	return nil
+}
+
+// accountCreatedAt normalises the account's creation time, which the API
+// models as a string
+func accountCreatedAt(acc up.Account) string {
+	t, err := time.Parse(time.RFC3339, acc.Attributes.CreatedAt)
+	if err != nil {
+		return acc.Attributes.CreatedAt
+	}
+	return formatTime(t)
+}
+
+func (s *Syncer) writeCategories(ctx context.Context, tx *sql.Tx, categories []up.Category, syncedAt string) error {
+	for _, c := range categories {
+		raw, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		var parentID *string
+		if c.Relationships.Parent.Data != nil {
+			parentID = &c.Relationships.Parent.Data.ID
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO categories (id, name, parent_id, raw, synced_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				name = excluded.name,
+				parent_id = excluded.parent_id,
+				raw = excluded.raw,
+				synced_at = excluded.synced_at`,
+			c.ID, c.Attributes.Name, parentID, string(raw), syncedAt)
+		if err != nil {
+			return fmt.Errorf("upsync: saving category %s: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) writeTags(ctx context.Context, tx *sql.Tx, tags []up.Tag, syncedAt string) error {
+	for _, t := range tags {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO tags (id, synced_at) VALUES (?, ?)
+			ON CONFLICT (id) DO UPDATE SET synced_at = excluded.synced_at`,
+			t.ID, syncedAt)
+		if err != nil {
+			return fmt.Errorf("upsync: saving tag %s: %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+// writeTransaction upserts t and its tags and counts it in cp
+func (s *Syncer) writeTransaction(ctx context.Context, tx *sql.Tx, t *up.Transaction, syncedAt string, cp *Checkpoint) error {
+	raw, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	var (
+		prevRaw   string
+		deletedAt sql.NullString
+	)
+	err = tx.QueryRowContext(ctx, "SELECT raw, deleted_at FROM transactions WHERE id = ?", t.ID).Scan(&prevRaw, &deletedAt)
+	switch {
+	case errNoRows(err):
+		cp.Inserted++
+	case err != nil:
+		return err
+	case prevRaw == string(raw) && !deletedAt.Valid:
+		_, err := tx.ExecContext(ctx, "UPDATE transactions SET synced_at = ? WHERE id = ?", syncedAt, t.ID)
+		return err
+	default:
+		prev, err := storedState(ctx, tx, t.ID)
+		if err != nil {
+			return err
+		}
+		next := stateOf(t)
+		if err := recordChanges(ctx, tx, t.ID, syncedAt, prev, next); err != nil {
+			return err
+		}
+		if deletedAt.Valid {
+			// Listed again after being tombstoned, the deletion was a false alarm
+			if err := recordChange(ctx, tx, t.ID, syncedAt, FieldDeleted, deletedAt.String, ""); err != nil {
+				return err
+			}
+		}
+		cp.Updated++
+		if prev.status == string(up.TransactionStatusHeld) && next.status == string(up.TransactionStatusSettled) {
+			cp.Settled++
+		}
+	}
+
+	a := t.Attributes
+	var foreignAmount, foreignCurrency *string
+	if a.ForeignAmount != nil {
+		foreignAmount, foreignCurrency = &a.ForeignAmount.Value, &a.ForeignAmount.CurrencyCode
+	}
+	var transferAccountID *string
+	if r := t.Relationships.TransferAccount; r != nil && r.Data.ID != "" {
+		transferAccountID = &r.Data.ID
+	}
+	var settledAt *string
+	if a.SettledAt != nil {
+		settledAt = nullTime(*a.SettledAt)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO transactions (id, account_id, transfer_account_id, status, description, raw_text, message,
+			amount, amount_base_units, currency, foreign_amount, foreign_currency, category_id,
+			parent_category_id, created_at, settled_at, raw, first_seen_at, synced_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			account_id = excluded.account_id,
+			transfer_account_id = excluded.transfer_account_id,
+			status = excluded.status,
+			description = excluded.description,
+			raw_text = excluded.raw_text,
+			message = excluded.message,
+			amount = excluded.amount,
+			amount_base_units = excluded.amount_base_units,
+			currency = excluded.currency,
+			foreign_amount = excluded.foreign_amount,
+			foreign_currency = excluded.foreign_currency,
+			category_id = excluded.category_id,
+			parent_category_id = excluded.parent_category_id,
+			created_at = excluded.created_at,
+			settled_at = excluded.settled_at,
+			raw = excluded.raw,
+			synced_at = excluded.synced_at,
+			deleted_at = NULL`,
+		t.ID, t.Relationships.Account.Data.ID, transferAccountID, a.Status, a.Description, a.RawText, a.Message,
+		a.Amount.Value, a.Amount.ValueInBaseUnits, a.Amount.CurrencyCode, foreignAmount, foreignCurrency,
+		categoryID(t.Relationships.Category), categoryID(t.Relationships.ParentCategory),
+		formatTime(a.CreatedAt), settledAt, string(raw), syncedAt, syncedAt)
+	if err != nil {
+		return fmt.Errorf("upsync: saving transaction %s: %w", t.ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM transaction_tags WHERE transaction_id = ?", t.ID); err != nil {
+		return err
+	}
+	for _, tagID := range t.Relationships.Tags.TagIDs() {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO transaction_tags (transaction_id, tag_id) VALUES (?, ?)", t.ID, tagID); err != nil {
+			return fmt.Errorf("upsync: saving tags of transaction %s: %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+func categoryID(r *up.CategoryRelationship) *string {
+	if r == nil || r.Data == nil {
+		return nil
+	}
+	return &r.Data.ID
+}
@@ -0,0 +1,154 @@
+package upsync
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+	"github.com/jordanst3wart/up-client/uptest"
+)
+
+// fixtureBase is when the first default fixture transaction was created
+var fixtureBase = time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("AEDT", 11*60*60))
+
+// newMirror returns a fake server seeded with the default fixtures and a
+// syncer writing to a fresh database, with a one day overlap
+func newMirror(t *testing.T) (*uptest.Server, *Store, *Syncer) {
+	t.Helper()
+	srv := uptest.NewServer(nil)
+	t.Cleanup(srv.Close)
+
+	store, err := Open(filepath.Join(t.TempDir(), "up.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	now := fixtureBase.AddDate(0, 1, 0)
+	syncer := NewSyncer(srv.UpClient().API(), store, &Options{
+		Overlap: 24 * time.Hour,
+		Now:     func() time.Time { now = now.Add(time.Minute); return now },
+	})
+	return srv, store, syncer
+}
+
+func mustSync(t *testing.T, s *Syncer) *Checkpoint {
+	t.Helper()
+	cp, err := s.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	return cp
+}
+
+func settle(srv *uptest.Server, id string) {
+	srv.UpdateTransaction(id, func(tx *up.Transaction) {
+		settledAt := tx.Attributes.CreatedAt.Add(time.Hour)
+		tx.Attributes.Status = up.TransactionStatusSettled
+		tx.Attributes.SettledAt = &settledAt
+	})
+}
+
+func TestSyncFull(t *testing.T) {
+	_, store, syncer := newMirror(t)
+
+	cp := mustSync(t, syncer)
+	if !cp.Since.IsZero() {
+		t.Errorf("first sync since = %s, want a full sync", cp.Since)
+	}
+	if cp.Accounts != 2 || cp.Categories != 6 || cp.Tags != 2 || cp.Fetched != 5 || cp.Inserted != 5 {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+	if want := fixtureBase.Add(4 * 24 * time.Hour); !cp.Newest.Equal(want) {
+		t.Errorf("newest = %s, want %s", cp.Newest, want)
+	}
+
+	last, err := store.LastCheckpoint(context.Background())
+	if err != nil || last == nil || last.ID != cp.ID {
+		t.Fatalf("LastCheckpoint = %+v, %v, want run %d", last, err, cp.ID)
+	}
+
+	var tagged int
+	if err := store.DB().QueryRow("SELECT COUNT(*) FROM transaction_tags").Scan(&tagged); err != nil || tagged != 2 {
+		t.Errorf("transaction tags = %d, %v, want 2", tagged, err)
+	}
+}
+
+func TestSyncIncrementalSince(t *testing.T) {
+	srv, _, syncer := newMirror(t)
+	settle(srv, "tx-5")
+	mustSync(t, syncer)
+
+	srv.AddTransaction(uptest.NewTransaction("tx-6", "acc-spending", "Bakery", "-6.00",
+		up.TransactionStatusSettled, fixtureBase.Add(10*24*time.Hour)))
+
+	cp := mustSync(t, syncer)
+	// Newest after the first run was tx-5, four days in, less the overlap
+	if want := fixtureBase.Add(3 * 24 * time.Hour); !cp.Since.Equal(want) {
+		t.Errorf("since = %s, want %s", cp.Since, want)
+	}
+	// tx-4 and tx-5 are in the overlap, tx-6 is new
+	if cp.Fetched != 3 || cp.Inserted != 1 || cp.Updated != 0 || cp.Deleted != 0 {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+	if want := fixtureBase.Add(10 * 24 * time.Hour); !cp.Newest.Equal(want) {
+		t.Errorf("newest = %s, want %s", cp.Newest, want)
+	}
+}
+
+func TestSyncWidensToHeld(t *testing.T) {
+	srv, store, syncer := newMirror(t)
+	srv.AddTransaction(uptest.NewTransaction("tx-6", "acc-spending", "Bakery", "-6.00",
+		up.TransactionStatusSettled, fixtureBase.Add(10*24*time.Hour)))
+	mustSync(t, syncer)
+
+	// tx-5 is still HELD and older than the overlap window
+	settle(srv, "tx-5")
+	cp := mustSync(t, syncer)
+	if want := fixtureBase.Add(4*24*time.Hour - heldMargin); !cp.Since.Equal(want) {
+		t.Errorf("since = %s, want just before held tx-5 (%s)", cp.Since, want)
+	}
+	if cp.Fetched != 2 || cp.Updated != 1 || cp.Settled != 1 {
+		t.Errorf("checkpoint = %+v, want tx-5 updated and settled", cp)
+	}
+
+	var status string
+	if err := store.DB().QueryRow("SELECT status FROM transactions WHERE id = 'tx-5'").Scan(&status); err != nil || status != "SETTLED" {
+		t.Errorf("tx-5 status = %q, %v", status, err)
+	}
+	changes, err := store.History(context.Background(), "tx-5")
+	if err != nil || len(changes) != 1 || changes[0].Field != FieldStatus || changes[0].Old != "HELD" || changes[0].New != "SETTLED" {
+		t.Errorf("history = %+v, %v, want HELD to SETTLED", changes, err)
+	}
+
+	// Nothing is held any more, so the window shrinks back to the overlap
+	cp = mustSync(t, syncer)
+	if want := fixtureBase.Add(9 * 24 * time.Hour); !cp.Since.Equal(want) {
+		t.Errorf("since = %s, want %s", cp.Since, want)
+	}
+	if cp.Settled != 0 || cp.Updated != 0 {
+		t.Errorf("checkpoint = %+v, want nothing changed", cp)
+	}
+}
+
+func TestSyncFailureKeepsCheckpoint(t *testing.T) {
+	srv, store, syncer := newMirror(t)
+	first := mustSync(t, syncer)
+
+	srv.Close()
+	cp, err := syncer.Sync(context.Background())
+	if err == nil || cp.Err == "" {
+		t.Fatalf("Sync against a closed server = %+v, %v, want an error", cp, err)
+	}
+
+	last, err := store.LastCheckpoint(context.Background())
+	if err != nil || last.ID != first.ID {
+		t.Errorf("LastCheckpoint = %+v, %v, want the successful run %d", last, err, first.ID)
+	}
+	cps, err := store.Checkpoints(context.Background(), 10)
+	if err != nil || len(cps) != 2 {
+		t.Errorf("Checkpoints = %d, %v, want both runs", len(cps), err)
+	}
+}
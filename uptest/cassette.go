@@ -0,0 +1,300 @@
+package uptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the network
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the network
+	ModeReplay Mode = iota
+	// ModeRecord sends every request and saves the exchanges to the cassette
+	ModeRecord
+	// ModeAuto replays if the cassette file exists and records otherwise
+	ModeAuto
+)
+
+// redacted replaces sensitive values in recorded cassettes
+const redacted = "REDACTED"
+
+// DefaultRedactKeys are the JSON object keys whose values are redacted from
+// recorded bodies: merchant and account names and other free text that can
+// identify people, card details, and webhook URLs and secrets
+var DefaultRedactKeys = []string{
+	"rawText", "description", "message", "displayName", "name",
+	"cardNumberSuffix", "url", "secretKey",
+}
+
+// Cassette holds recorded HTTP exchanges
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request used for matching
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Query is the encoded query string with parameters sorted by key
+	Query string `json:"query,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderOptions specifies the optional parameters for a Recorder
+type RecorderOptions struct {
+	Mode Mode
+	// Transport sends requests when recording. http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+	// RedactKeys lists JSON object keys whose values are replaced before
+	// saving. DefaultRedactKeys is used when nil; append to it to redact more.
+	RedactKeys []string
+}
+
+// Recorder is an http.RoundTripper that records exchanges to a cassette file
+// or replays them offline. Use it with up.WithTransport:
+//
+//	rec, err := uptest.NewRecorder("testdata/transactions.json", nil)
+//	defer rec.Stop()
+//	client, err := up.New(token, up.WithTransport(rec))
+//
+// Request headers, including the bearer token, are never recorded, and the
+// values of RedactKeys are replaced in request and response bodies, including
+// JSON documents held in strings such as delivery log request bodies.
+// Requests are matched on method, path and query string, so filters such as
+// filter[since] must be identical on replay. Repeated identical requests are
+// answered in recorded order, with the last answer reused once exhausted.
+type Recorder struct {
+	path       string
+	mode       Mode
+	transport  http.RoundTripper
+	redactKeys map[string]bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a recorder for the cassette file at path
+func NewRecorder(path string, opts *RecorderOptions) (*Recorder, error) {
+	if opts == nil {
+		opts = &RecorderOptions{}
+	}
+
+	r := &Recorder{
+		path:       path,
+		mode:       opts.Mode,
+		transport:  opts.Transport,
+		redactKeys: make(map[string]bool),
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	keys := opts.RedactKeys
+	if keys == nil {
+		keys = DefaultRedactKeys
+	}
+	for _, k := range keys {
+		r.redactKeys[k] = true
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && r.mode != ModeReplay:
+		r.mode = ModeRecord
+	case err != nil:
+		return nil, fmt.Errorf("uptest: reading cassette: %w", err)
+	case r.mode == ModeRecord:
+		// Start afresh, the existing cassette is overwritten on Stop
+	default:
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("uptest: decoding cassette %s: %w", path, err)
+		}
+		r.mode = ModeReplay
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Recording reports whether the recorder is sending requests to the network
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Body:   r.redact(body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	// Content-Length no longer matches once the body is redacted
+	for _, h := range []string{"Set-Cookie", "Date", "Content-Length"} {
+		header.Del(h)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       r.redact(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, in := range r.cassette.Interactions {
+		if in.Request.Method != recorded.Method || in.Request.Path != recorded.Path || in.Request.Query != recorded.Query {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("uptest: no recorded interaction for %s %s?%s in %s", recorded.Method, recorded.Path, recorded.Query, r.path)
+	}
+	r.used[match] = true
+
+	in := r.cassette.Interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+		StatusCode:    in.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        in.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(in.Body))),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Stop saves the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(&r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// redact replaces the values of sensitive keys in a JSON body. Bodies that
+// are not JSON are kept as they are.
+func (r *Recorder) redact(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return string(body)
+	}
+	out, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+func (r *Recorder) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if r.redactKeys[k] && child != nil {
+				v[k] = redacted
+				continue
+			}
+			v[k] = r.redactValue(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.redactValue(child)
+		}
+	case string:
+		// Strings holding a JSON object or array, such as the event in a
+		// delivery log, are redacted too
+		if trimmed := strings.TrimSpace(v); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			return r.redact([]byte(v))
+		}
+	}
+	return v
+}
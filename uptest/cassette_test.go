@@ -0,0 +1,129 @@
+package uptest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	since := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer receiver.Close()
+
+	// Record against the fake server
+	srv := NewServer(nil)
+	rec, err := NewRecorder(path, &RecorderOptions{Mode: ModeAuto})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.Recording() {
+		t.Fatal("recorder is not recording without a cassette")
+	}
+	client := srv.UpClient(up.WithTransport(rec))
+
+	recorded, _, err := client.Transactions.List(ctx, &up.ListTransactionsOptions{Since: &since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	description := "personal ledger"
+	wh, _, err := client.Webhooks.Create(ctx, receiver.URL+"/private-hook", &description)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Webhooks.Ping(ctx, wh.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Webhooks.ListLogs(ctx, wh.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{
+		Token, "Salary", "Shell Coles Express", "personal ledger", "private-hook", wh.Attributes.SecretKey,
+	} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	// Replay with the server gone
+	rec, err = NewRecorder(path, &RecorderOptions{Mode: ModeAuto})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Recording() {
+		t.Fatal("recorder is recording although the cassette exists")
+	}
+	client = srv.UpClient(up.WithTransport(rec))
+
+	replayed, _, err := client.Transactions.List(ctx, &up.ListTransactionsOptions{Since: &since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := transactionIDs(replayed.Data), transactionIDs(recorded.Data); strings.Join(got, ",") != strings.Join(want, ",") || len(got) == 0 {
+		t.Errorf("replayed %v, recorded %v", got, want)
+	}
+	if replayed.Data[0].Attributes.Description != redacted || replayed.Data[0].Attributes.Amount.Value == "" {
+		t.Errorf("replayed transaction = %+v, want the description redacted and the amount kept", replayed.Data[0].Attributes)
+	}
+
+	logs, _, err := client.Webhooks.ListLogs(ctx, wh.ID, nil)
+	if err != nil || len(logs.Data) != 1 {
+		t.Fatalf("replayed logs = %v, %v", logs, err)
+	}
+	if _, err := up.ParseWebhookEvent([]byte(logs.Data[0].Attributes.Request.Body)); err != nil {
+		t.Errorf("replayed delivery log body is no longer an event: %v", err)
+	}
+
+	// Requests are matched on their filters
+	other := since.Add(time.Hour)
+	if _, _, err := client.Transactions.List(ctx, &up.ListTransactionsOptions{Since: &other}); err == nil {
+		t.Error("a request with a different filter[since] was answered")
+	}
+	if _, _, err := client.Transactions.List(ctx, nil); err == nil {
+		t.Error("a request without filter[since] was answered")
+	}
+}
+
+func TestRecorderRedact(t *testing.T) {
+	rec, err := NewRecorder(filepath.Join(t.TempDir(), "cassette.json"), &RecorderOptions{Mode: ModeRecord})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct{ in, want string }{
+		{`{"data":{"attributes":{"description":"Cafe","amount":{"value":"-4.50"}}}}`,
+			`{"data":{"attributes":{"amount":{"value":"-4.50"},"description":"REDACTED"}}}`},
+		{`{"data":[{"attributes":{"displayName":"Alex's Spending","message":null}}]}`,
+			`{"data":[{"attributes":{"displayName":"REDACTED","message":null}}]}`},
+		// Delivery logs hold the event as a JSON string
+		{`{"request":{"body":"{\"data\":{\"attributes\":{\"url\":\"https://me.example\",\"id\":\"1\"}}}"}}`,
+			`{"request":{"body":"{\"data\":{\"attributes\":{\"id\":\"1\",\"url\":\"REDACTED\"}}}"}}`},
+		{`{"response":{"body":"{not json"}}`, `{"response":{"body":"{not json"}}`},
+		{`{"valueInBaseUnits":12345678901234567890}`, `{"valueInBaseUnits":12345678901234567890}`},
+		{`not json`, `not json`},
+	}
+	for _, tt := range tests {
+		if got := rec.redact([]byte(tt.in)); got != tt.want {
+			t.Errorf("redact(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package uptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+// Fixtures seed the state of a fake server. Transactions reference accounts,
+// categories and tags by ID; tags used by transactions are created implicitly.
+type Fixtures struct {
+	Accounts     []up.Account     `json:"accounts"`
+	Transactions []up.Transaction `json:"transactions"`
+	Categories   []up.Category    `json:"categories"`
+	Tags         []string         `json:"tags"`
+	Webhooks     []up.Webhook     `json:"webhooks"`
+}
+
+// LoadFixtures decodes fixtures from JSON
+func LoadFixtures(r io.Reader) (*Fixtures, error) {
+	var f Fixtures
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("uptest: decoding fixtures: %w", err)
+	}
+	return &f, nil
+}
+
+// DefaultFixtures returns a small, realistic data set: a spending and a
+// saver account, a handful of categories and transactions, and two tags
+func DefaultFixtures() *Fixtures {
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("AEDT", 11*60*60))
+
+	f := &Fixtures{
+		Accounts: []up.Account{
+			NewAccount("acc-spending", "Spending", up.AccountTypeTransactional, "1024.50", base.AddDate(-1, 0, 0)),
+			NewAccount("acc-saver", "Rainy Day", up.AccountTypeSaver, "5000.00", base.AddDate(-1, 0, 0)),
+		},
+		Categories: []up.Category{
+			NewCategory("good-life", "Good Life", ""),
+			NewCategory("restaurants-and-cafes", "Restaurants & Cafes", "good-life"),
+			NewCategory("home", "Home", ""),
+			NewCategory("groceries", "Groceries", "home"),
+			NewCategory("transport", "Transport", ""),
+			NewCategory("fuel", "Fuel", "transport"),
+		},
+		Tags: []string{"Holiday", "Work"},
+	}
+
+	txs := []struct {
+		id, description, amount, category string
+		status                            up.TransactionStatusEnum
+		tags                              []string
+	}{
+		{"tx-1", "Cafe Sydney", "-4.50", "restaurants-and-cafes", up.TransactionStatusSettled, nil},
+		{"tx-2", "Woolworths", "-87.20", "groceries", up.TransactionStatusSettled, nil},
+		{"tx-3", "Salary", "3200.00", "", up.TransactionStatusSettled, []string{"Work"}},
+		{"tx-4", "Shell Coles Express", "-65.20", "fuel", up.TransactionStatusSettled, []string{"Holiday"}},
+		{"tx-5", "Uber Eats", "-32.10", "restaurants-and-cafes", up.TransactionStatusHeld, nil},
+	}
+	for i, tx := range txs {
+		t := NewTransaction(tx.id, "acc-spending", tx.description, tx.amount, tx.status, base.Add(time.Duration(i)*24*time.Hour))
+		if tx.category != "" {
+			SetCategory(&t, tx.category, parentOf(f.Categories, tx.category))
+		}
+		for _, tag := range tx.tags {
+			t.Relationships.Tags.Data = append(t.Relationships.Tags.Data, up.TagInputResource{Type: "tags", ID: tag})
+		}
+		f.Transactions = append(f.Transactions, t)
+	}
+
+	return f
+}
+
+// NewAccount builds an account resource
+func NewAccount(id, name string, accountType up.AccountTypeEnum, balance string, createdAt time.Time) up.Account {
+	m, err := up.ParseMoney("AUD", balance)
+	if err != nil {
+		panic(err)
+	}
+	return up.Account{
+		Type: "accounts",
+		ID:   id,
+		Attributes: up.AccountAttributes{
+			DisplayName:   name,
+			AccountType:   accountType,
+			OwnershipType: up.OwnershipTypeIndividual,
+			Balance:       m.MoneyObject(),
+			CreatedAt:     createdAt.Format(time.RFC3339),
+		},
+	}
+}
+
+// NewCategory builds a category resource. parentID is empty for top level categories.
+func NewCategory(id, name, parentID string) up.Category {
+	c := up.Category{
+		Type:       "categories",
+		ID:         id,
+		Attributes: up.CategoryAttributes{Name: name},
+	}
+	if parentID != "" {
+		c.Relationships.Parent.Data = &up.CategoryData{Type: "categories", ID: parentID}
+	}
+	c.Relationships.Children.Data = []up.CategoryData{}
+	return c
+}
+
+// NewTransaction builds a transaction resource in AUD. amount is a decimal such as "-4.50".
+func NewTransaction(id, accountID, description, amount string, status up.TransactionStatusEnum, createdAt time.Time) up.Transaction {
+	m, err := up.ParseMoney("AUD", amount)
+	if err != nil {
+		panic(err)
+	}
+	rawText := description
+	tx := up.Transaction{
+		Type: "transactions",
+		ID:   id,
+		Attributes: up.TransactionAttributes{
+			Status:          status,
+			RawText:         &rawText,
+			Description:     description,
+			IsCategorizable: true,
+			Amount:          m.MoneyObject(),
+			CreatedAt:       createdAt,
+		},
+	}
+	if status == up.TransactionStatusSettled {
+		settledAt := createdAt.Add(time.Hour)
+		tx.Attributes.SettledAt = &settledAt
+	}
+	tx.Relationships.Account.Data = up.AccountData{Type: "accounts", ID: accountID}
+	tx.Relationships.Tags.Data = []up.TagInputResource{}
+	return tx
+}
+
+// SetCategory sets the category and parent category of tx
+func SetCategory(tx *up.Transaction, categoryID, parentID string) {
+	tx.Relationships.Category = &up.CategoryRelationship{Data: &up.CategoryData{Type: "categories", ID: categoryID}}
+	tx.Relationships.ParentCategory = &up.CategoryRelationship{}
+	if parentID != "" {
+		tx.Relationships.ParentCategory.Data = &up.CategoryData{Type: "categories", ID: parentID}
+	}
+}
+
+func parentOf(categories []up.Category, id string) string {
+	for _, c := range categories {
+		if c.ID == id && c.Relationships.Parent.Data != nil {
+			return c.Relationships.Parent.Data.ID
+		}
+	}
+	return ""
+}
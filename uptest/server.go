@@ -0,0 +1,604 @@
+// Package uptest provides a stateful, in-memory fake of the Up API for
+// integration tests. It implements every endpoint used by the up package,
+// including pagination, transaction filters, category and tag updates and
+// webhook management with real, signed deliveries.
+//
+//	srv := uptest.NewServer(nil)
+//	defer srv.Close()
+//	client := srv.UpClient()
+//	accounts, _, err := client.Accounts.List(ctx, nil)
+package uptest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+const (
+	// Token is the bearer token the fake server accepts
+	Token = "uptest-token"
+
+	apiPrefix       = "/api/v1"
+	defaultPageSize = 10
+	maxPageSize     = 100
+	maxTagsPerTx    = 6
+	maxWebhooks     = 10
+)
+
+// Server is a fake Up API backed by in-memory state
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	accounts     []up.Account
+	transactions []up.Transaction // newest first
+	categories   []up.Category
+	tags         []string // sorted
+	webhooks     []up.Webhook
+	logs         map[string][]up.WebhookDeliveryLog // by webhook ID, newest first
+	nextID       int
+
+	// Now returns the time used for created resources and events
+	Now func() time.Time
+	// DeliveryClient sends webhook events to registered webhook URLs
+	DeliveryClient *http.Client
+}
+
+// NewServer starts a fake server seeded with fixtures, or DefaultFixtures if nil
+func NewServer(fixtures *Fixtures) *Server {
+	if fixtures == nil {
+		fixtures = DefaultFixtures()
+	}
+
+	s := &Server{
+		logs:           make(map[string][]up.WebhookDeliveryLog),
+		Now:            time.Now,
+		DeliveryClient: &http.Client{Timeout: 5 * time.Second},
+	}
+	s.seed(fixtures)
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// UpClient returns an up.Client pointed at the fake server, with retries disabled
+func (s *Server) UpClient(opts ...up.Option) *up.Client {
+	opts = append([]up.Option{
+		up.WithBaseURL(s.URL + apiPrefix + "/"),
+		up.WithRetryPolicy(up.NoRetry()),
+	}, opts...)
+
+	c, err := up.New(Token, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("uptest: creating client: %v", err))
+	}
+	return c
+}
+
+func (s *Server) seed(f *Fixtures) {
+	s.accounts = slices.Clone(f.Accounts)
+	s.categories = slices.Clone(f.Categories)
+	s.webhooks = slices.Clone(f.Webhooks)
+	s.tags = slices.Clone(f.Tags)
+
+	// Derive children from parents so fixtures only need to set one side
+	for i := range s.categories {
+		s.categories[i].Relationships.Children.Data = []up.CategoryData{}
+	}
+	for _, c := range s.categories {
+		if c.Relationships.Parent.Data == nil {
+			continue
+		}
+		if parent := s.category(c.Relationships.Parent.Data.ID); parent != nil {
+			parent.Relationships.Children.Data = append(parent.Relationships.Children.Data, up.CategoryData{Type: "categories", ID: c.ID})
+		}
+	}
+
+	for _, tx := range f.Transactions {
+		s.insertTransaction(tx)
+	}
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+apiPrefix+path, h)
+	}
+
+	handle("GET /util/ping", s.ping)
+	handle("GET /accounts", s.listAccounts)
+	handle("GET /accounts/{id}", s.getAccount)
+	handle("GET /accounts/{id}/transactions", s.listTransactions)
+	handle("GET /transactions", s.listTransactions)
+	handle("GET /transactions/{id}", s.getTransaction)
+	handle("PATCH /transactions/{id}/relationships/category", s.updateCategory)
+	handle("POST /transactions/{id}/relationships/tags", s.addTags)
+	handle("DELETE /transactions/{id}/relationships/tags", s.removeTags)
+	handle("GET /categories", s.listCategories)
+	handle("GET /categories/{id}", s.getCategory)
+	handle("GET /tags", s.listTags)
+	handle("GET /webhooks", s.listWebhooks)
+	handle("POST /webhooks", s.createWebhook)
+	handle("GET /webhooks/{id}", s.getWebhook)
+	handle("DELETE /webhooks/{id}", s.deleteWebhook)
+	handle("POST /webhooks/{id}/ping", s.pingWebhook)
+	handle("GET /webhooks/{id}/logs", s.listWebhookLogs)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "Not Authorized", "The request was not authenticated because no valid credential was found in the Authorization header, or the Authorization header was not present.")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) ping(w http.ResponseWriter, r *http.Request) {
+	var resp up.PingResponse
+	resp.Meta.ID = "00000000-0000-0000-0000-000000000000"
+	resp.Meta.StatusEmoji = "⚡️"
+	writeJSON(w, http.StatusOK, &resp)
+}
+
+func (s *Server) listAccounts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	var accounts []up.Account
+	for _, a := range s.accounts {
+		if v := q.Get("filter[accountType]"); v != "" && string(a.Attributes.AccountType) != v {
+			continue
+		}
+		if v := q.Get("filter[ownershipType]"); v != "" && string(a.Attributes.OwnershipType) != v {
+			continue
+		}
+		accounts = append(accounts, a)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, s.URL, accounts)
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.accounts {
+		if a.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, &up.AccountGetResponse{Data: a})
+			return
+		}
+	}
+	writeNotFound(w, "account")
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var since, until time.Time
+	for param, t := range map[string]*time.Time{"filter[since]": &since, "filter[until]": &until} {
+		if v := q.Get(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "Invalid Parameter", fmt.Sprintf("%s must be an RFC 3339 date-time", param))
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	status := q.Get("filter[status]")
+	if status != "" && status != string(up.TransactionStatusHeld) && status != string(up.TransactionStatusSettled) {
+		writeError(w, http.StatusBadRequest, "Invalid Parameter", "filter[status] must be HELD or SETTLED")
+		return
+	}
+
+	s.mu.Lock()
+	accountID := r.PathValue("id")
+	if accountID != "" && s.account(accountID) == nil {
+		s.mu.Unlock()
+		writeNotFound(w, "account")
+		return
+	}
+
+	var txs []up.Transaction
+	for _, tx := range s.transactions {
+		attrs, rels := tx.Attributes, tx.Relationships
+		if accountID != "" && rels.Account.Data.ID != accountID {
+			continue
+		}
+		if status != "" && string(attrs.Status) != status {
+			continue
+		}
+		if !since.IsZero() && attrs.CreatedAt.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !attrs.CreatedAt.Before(until) {
+			continue
+		}
+		if v := q.Get("filter[category]"); v != "" && !inCategory(tx, v) {
+			continue
+		}
+		if v := q.Get("filter[tag]"); v != "" && !slices.Contains(rels.Tags.TagIDs(), v) {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, s.URL, txs)
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, r *http.Request) {
+	tx, ok := s.Transaction(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "transaction")
+		return
+	}
+	writeJSON(w, http.StatusOK, &up.TransactionGetResponse{Data: tx})
+}
+
+func (s *Server) updateCategory(w http.ResponseWriter, r *http.Request) {
+	var body up.CategoryUpdateRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.transaction(r.PathValue("id"))
+	if tx == nil {
+		writeNotFound(w, "transaction")
+		return
+	}
+	if !tx.Attributes.IsCategorizable {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request", "This transaction cannot be categorized.")
+		return
+	}
+
+	if body.Data == nil {
+		tx.Relationships.Category = &up.CategoryRelationship{}
+		tx.Relationships.ParentCategory = &up.CategoryRelationship{}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	category := s.category(body.Data.ID)
+	if body.Data.Type != "categories" || category == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request", "The category does not exist.")
+		return
+	}
+	if category.Relationships.Parent.Data == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request", "Transactions can only be assigned to a child category.")
+		return
+	}
+
+	tx.Relationships.Category = &up.CategoryRelationship{Data: &up.CategoryData{Type: "categories", ID: category.ID}}
+	tx.Relationships.ParentCategory = &up.CategoryRelationship{Data: &up.CategoryData{Type: "categories", ID: category.Relationships.Parent.Data.ID}}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) addTags(w http.ResponseWriter, r *http.Request) {
+	s.updateTags(w, r, func(tags []up.TagInputResource, tag up.TagInputResource) []up.TagInputResource {
+		if slices.Contains(tags, tag) {
+			return tags
+		}
+		return append(tags, tag)
+	})
+}
+
+func (s *Server) removeTags(w http.ResponseWriter, r *http.Request) {
+	s.updateTags(w, r, func(tags []up.TagInputResource, tag up.TagInputResource) []up.TagInputResource {
+		return slices.DeleteFunc(tags, func(t up.TagInputResource) bool { return t == tag })
+	})
+}
+
+func (s *Server) updateTags(w http.ResponseWriter, r *http.Request, apply func([]up.TagInputResource, up.TagInputResource) []up.TagInputResource) {
+	var body up.UpdateTransactionTagsRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	for _, tag := range body.Data {
+		if tag.Type != "tags" || tag.ID == "" {
+			writeError(w, http.StatusBadRequest, "Invalid Request", "Each tag must have type \"tags\" and an id.")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.transaction(r.PathValue("id"))
+	if tx == nil {
+		writeNotFound(w, "transaction")
+		return
+	}
+
+	tags := slices.Clone(tx.Relationships.Tags.Data)
+	for _, tag := range body.Data {
+		tags = apply(tags, tag)
+	}
+	if len(tags) > maxTagsPerTx {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request", fmt.Sprintf("A transaction can have at most %d tags.", maxTagsPerTx))
+		return
+	}
+
+	tx.Relationships.Tags.Data = tags
+	for _, tag := range tags {
+		s.addTag(tag.ID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listCategories(w http.ResponseWriter, r *http.Request) {
+	parent := r.URL.Query().Get("filter[parent]")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if parent != "" && s.category(parent) == nil {
+		writeNotFound(w, "category")
+		return
+	}
+
+	categories := []up.Category{}
+	for _, c := range s.categories {
+		if parent != "" && (c.Relationships.Parent.Data == nil || c.Relationships.Parent.Data.ID != parent) {
+			continue
+		}
+		categories = append(categories, c)
+	}
+	writeJSON(w, http.StatusOK, &up.CategoryListResponse{Data: categories})
+}
+
+func (s *Server) getCategory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.category(r.PathValue("id"))
+	if c == nil {
+		writeNotFound(w, "category")
+		return
+	}
+	writeJSON(w, http.StatusOK, &up.CategoryGetResponse{Data: *c})
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	tags := make([]up.Tag, len(s.tags))
+	for i, id := range s.tags {
+		tags[i] = up.Tag{Type: "tags", ID: id}
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, s.URL, tags)
+}
+
+// AddTransaction inserts or replaces a transaction
+func (s *Server) AddTransaction(tx up.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeTransaction(tx.ID)
+	s.insertTransaction(tx)
+}
+
+// UpdateTransaction applies fn to the stored transaction with the given ID
+// and reports whether it exists
+func (s *Server) UpdateTransaction(id string, fn func(tx *up.Transaction)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.transaction(id)
+	if tx == nil {
+		return false
+	}
+	fn(tx)
+	updated := *tx
+	s.removeTransaction(id)
+	s.insertTransaction(updated)
+	return true
+}
+
+// DeleteTransaction removes a transaction and reports whether it existed
+func (s *Server) DeleteTransaction(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeTransaction(id)
+}
+
+// Transaction returns a copy of the stored transaction with the given ID
+func (s *Server) Transaction(id string) (up.Transaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.transaction(id)
+	if tx == nil {
+		return up.Transaction{}, false
+	}
+	return *tx, true
+}
+
+// Transactions returns a copy of every stored transaction, newest first
+func (s *Server) Transactions() []up.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.transactions)
+}
+
+func (s *Server) insertTransaction(tx up.Transaction) {
+	if tx.Type == "" {
+		tx.Type = "transactions"
+	}
+	if tx.Relationships.Tags.Data == nil {
+		tx.Relationships.Tags.Data = []up.TagInputResource{}
+	}
+	for _, tag := range tx.Relationships.Tags.Data {
+		s.addTag(tag.ID)
+	}
+
+	i, _ := slices.BinarySearchFunc(s.transactions, tx, func(a, b up.Transaction) int {
+		return b.Attributes.CreatedAt.Compare(a.Attributes.CreatedAt)
+	})
+	s.transactions = slices.Insert(s.transactions, i, tx)
+}
+
+func (s *Server) removeTransaction(id string) bool {
+	n := len(s.transactions)
+	s.transactions = slices.DeleteFunc(s.transactions, func(tx up.Transaction) bool { return tx.ID == id })
+	return len(s.transactions) != n
+}
+
+func (s *Server) transaction(id string) *up.Transaction {
+	for i := range s.transactions {
+		if s.transactions[i].ID == id {
+			return &s.transactions[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) account(id string) *up.Account {
+	for i := range s.accounts {
+		if s.accounts[i].ID == id {
+			return &s.accounts[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) category(id string) *up.Category {
+	for i := range s.categories {
+		if s.categories[i].ID == id {
+			return &s.categories[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) addTag(id string) {
+	if i, found := slices.BinarySearch(s.tags, id); !found {
+		s.tags = slices.Insert(s.tags, i, id)
+	}
+}
+
+// newID returns a unique identifier for a created resource
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func inCategory(tx up.Transaction, id string) bool {
+	rels := tx.Relationships
+	if rels.Category != nil && rels.Category.Data != nil && rels.Category.Data.ID == id {
+		return true
+	}
+	return rels.ParentCategory != nil && rels.ParentCategory.Data != nil && rels.ParentCategory.Data.ID == id
+}
+
+// writePage writes the page of items selected by the request's page
+// parameters, with links to the neighbouring pages
+func writePage[T any](w http.ResponseWriter, r *http.Request, serverURL string, items []T) {
+	q := r.URL.Query()
+
+	size := defaultPageSize
+	if v := q.Get("page[size]"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			writeError(w, http.StatusBadRequest, "Invalid Parameter", fmt.Sprintf("page[size] must be between 1 and %d", maxPageSize))
+			return
+		}
+		size = n
+	}
+
+	start, end := 0, min(size, len(items))
+	if v := q.Get("page[after]"); v != "" {
+		offset, ok := decodeCursor(v, len(items))
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid Parameter", "page[after] is not a valid cursor")
+			return
+		}
+		start, end = offset, min(offset+size, len(items))
+	} else if v := q.Get("page[before]"); v != "" {
+		offset, ok := decodeCursor(v, len(items))
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid Parameter", "page[before] is not a valid cursor")
+			return
+		}
+		start, end = max(0, offset-size), offset
+	}
+
+	page := up.ListResponse[T]{Data: items[start:end]}
+	if page.Data == nil {
+		page.Data = []T{}
+	}
+	if end < len(items) {
+		page.Links.Next = pageLink(serverURL, r, "page[after]", end)
+	}
+	if start > 0 {
+		page.Links.Prev = pageLink(serverURL, r, "page[before]", start)
+	}
+	writeJSON(w, http.StatusOK, &page)
+}
+
+func pageLink(serverURL string, r *http.Request, param string, offset int) string {
+	q := r.URL.Query()
+	q.Del("page[after]")
+	q.Del("page[before]")
+	q.Set(param, base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset))))
+
+	u, _ := url.Parse(serverURL)
+	u.Path = r.URL.Path
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func decodeCursor(cursor string, n int) (int, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 || offset > n {
+		return 0, false
+	}
+	return offset, true
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Request", "The request body is not valid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeNotFound(w http.ResponseWriter, resource string) {
+	writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("The %s does not exist.", resource))
+}
+
+func writeError(w http.ResponseWriter, status int, title, detail string) {
+	writeJSON(w, status, &up.ErrorResponse{Errors: []up.ErrorObject{{
+		Status: strconv.Itoa(status),
+		Title:  title,
+		Detail: detail,
+	}}})
+}
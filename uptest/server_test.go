@@ -0,0 +1,282 @@
+package uptest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+func transactionIDs(txs []up.Transaction) []string {
+	ids := make([]string, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	return ids
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.UpClient()
+	ctx := context.Background()
+
+	resp, _, err := client.Transactions.List(ctx, &up.ListTransactionsOptions{ListOptions: up.ListOptions{PageSize: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"tx-5", "tx-4", "tx-3", "tx-2", "tx-1"}; !slices.Equal(transactionIDs(resp.Data), want) {
+		t.Errorf("listed %v, want %v", transactionIDs(resp.Data), want)
+	}
+	if resp.PagesFetched != 3 || resp.Links.Next != "" {
+		t.Errorf("PagesFetched = %d, Links.Next = %q, want 3 pages and no next link", resp.PagesFetched, resp.Links.Next)
+	}
+
+	// Walking back from the last page with Prev cursors returns the earlier items
+	last, _, err := client.Transactions.ListPage(ctx, &up.ListTransactionsOptions{ListOptions: up.ListOptions{PageSize: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, _, err := client.Transactions.ListPage(ctx, &up.ListTransactionsOptions{ListOptions: up.ListOptions{PageSize: 2, After: last.NextCursor}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev, _, err := client.Transactions.ListPage(ctx, &up.ListTransactionsOptions{ListOptions: up.ListOptions{PageSize: 2, Before: next.PrevCursor}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(transactionIDs(next.Data), []string{"tx-3", "tx-2"}) || !slices.Equal(transactionIDs(prev.Data), []string{"tx-5", "tx-4"}) {
+		t.Errorf("next page %v, previous page %v", transactionIDs(next.Data), transactionIDs(prev.Data))
+	}
+
+	if _, _, err := client.Tags.List(ctx, &up.ListOptions{PageSize: 101}); !errors.Is(err, up.ErrValidation) {
+		t.Errorf("page[size] above the maximum returned %v, want ErrValidation", err)
+	}
+}
+
+func TestServerFilters(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.UpClient()
+	ctx := context.Background()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("AEDT", 11*60*60))
+	since, until := base.Add(24*time.Hour), base.Add(3*24*time.Hour)
+
+	tests := []struct {
+		name string
+		opts up.ListTransactionsOptions
+		want []string
+	}{
+		{"status", up.ListTransactionsOptions{Status: up.TransactionStatusHeld}, []string{"tx-5"}},
+		{"since and until", up.ListTransactionsOptions{Since: &since, Until: &until}, []string{"tx-3", "tx-2"}},
+		{"child category", up.ListTransactionsOptions{Category: "groceries"}, []string{"tx-2"}},
+		{"parent category", up.ListTransactionsOptions{Category: "good-life"}, []string{"tx-5", "tx-1"}},
+		{"tag", up.ListTransactionsOptions{Tag: "Holiday"}, []string{"tx-4"}},
+	}
+	for _, tt := range tests {
+		resp, _, err := client.Transactions.List(ctx, &tt.opts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := transactionIDs(resp.Data); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: listed %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	byAccount, _, err := client.Transactions.ListByAccount(ctx, "acc-saver", nil)
+	if err != nil || len(byAccount.Data) != 0 {
+		t.Errorf("saver transactions = %v, %v, want none", byAccount, err)
+	}
+	if _, _, err := client.Transactions.ListByAccount(ctx, "acc-missing", nil); !errors.Is(err, up.ErrNotFound) {
+		t.Errorf("unknown account returned %v, want ErrNotFound", err)
+	}
+
+	savers, _, err := client.Accounts.List(ctx, &up.ListAccountsOptions{AccountType: up.AccountTypeSaver})
+	if err != nil || len(savers.Data) != 1 || savers.Data[0].ID != "acc-saver" {
+		t.Errorf("saver accounts = %v, %v", savers, err)
+	}
+
+	children, _, err := client.Categories.List(ctx, &up.ListCategoriesOptions{Parent: "home"})
+	if err != nil || len(children.Data) != 1 || children.Data[0].ID != "groceries" {
+		t.Errorf("children of home = %v, %v", children, err)
+	}
+}
+
+func TestServerUpdateCategory(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.UpClient()
+	ctx := context.Background()
+
+	if _, err := client.Categories.UpdateTransactionCategory(ctx, "tx-3", "fuel"); err != nil {
+		t.Fatal(err)
+	}
+	tx, _, err := client.Transactions.Get(ctx, "tx-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Relationships.Category.Data.ID != "fuel" || tx.Relationships.ParentCategory.Data.ID != "transport" {
+		t.Errorf("category = %+v, parent = %+v", tx.Relationships.Category.Data, tx.Relationships.ParentCategory.Data)
+	}
+
+	if _, err := client.Categories.RemoveTransactionCategory(ctx, "tx-3"); err != nil {
+		t.Fatal(err)
+	}
+	if tx, _ := srv.Transaction("tx-3"); tx.Relationships.Category.Data != nil {
+		t.Errorf("category was not removed: %+v", tx.Relationships.Category.Data)
+	}
+
+	if _, err := client.Categories.UpdateTransactionCategory(ctx, "tx-3", "transport"); !errors.Is(err, up.ErrValidation) {
+		t.Errorf("assigning a parent category returned %v, want ErrValidation", err)
+	}
+	if _, err := client.Categories.UpdateTransactionCategory(ctx, "tx-3", "missing"); !errors.Is(err, up.ErrValidation) {
+		t.Errorf("assigning an unknown category returned %v, want ErrValidation", err)
+	}
+	if _, err := client.Categories.UpdateTransactionCategory(ctx, "tx-missing", "fuel"); !errors.Is(err, up.ErrNotFound) {
+		t.Errorf("updating an unknown transaction returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestServerTags(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.UpClient()
+	ctx := context.Background()
+
+	if _, err := client.Tags.AddToTransaction(ctx, "tx-1", []string{"Coffee", "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	tx, _, err := client.Transactions.Get(ctx, "tx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tx.Relationships.Tags.TagIDs(); !slices.Equal(got, []string{"Coffee", "Work"}) {
+		t.Errorf("tags after adding = %v", got)
+	}
+
+	// New tags appear in the tag list
+	tags, _, err := client.Tags.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, tag := range tags.Data {
+		ids = append(ids, tag.ID)
+	}
+	if !slices.Equal(ids, []string{"Coffee", "Holiday", "Work"}) {
+		t.Errorf("tags = %v", ids)
+	}
+
+	if _, err := client.Tags.RemoveFromTransaction(ctx, "tx-1", []string{"Work"}); err != nil {
+		t.Fatal(err)
+	}
+	if tx, _ := srv.Transaction("tx-1"); !slices.Equal(tx.Relationships.Tags.TagIDs(), []string{"Coffee"}) {
+		t.Errorf("tags after removing = %v", tx.Relationships.Tags.TagIDs())
+	}
+
+	if _, err := client.Tags.AddToTransaction(ctx, "tx-1", []string{"a", "b", "c", "d", "e", "f"}); !errors.Is(err, up.ErrValidation) {
+		t.Errorf("adding a seventh tag returned %v, want ErrValidation", err)
+	}
+}
+
+func TestServerWebhooks(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.UpClient()
+	ctx := context.Background()
+
+	// The secret is only known once the webhook has been created
+	var (
+		received []*up.WebhookEvent
+		secret   string
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		up.NewWebhookHandler(secret, func(ctx context.Context, event *up.WebhookEvent) error {
+			received = append(received, event)
+			return nil
+		}).ServeHTTP(w, r)
+	}))
+	defer receiver.Close()
+
+	description := "ledger"
+	wh, _, err := client.Webhooks.Create(ctx, receiver.URL, &description)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wh.Attributes.SecretKey == "" || wh.Attributes.URL != receiver.URL {
+		t.Fatalf("created webhook = %+v", wh)
+	}
+	secret = wh.Attributes.SecretKey
+
+	got, _, err := client.Webhooks.Get(ctx, wh.ID)
+	if err != nil || got.Attributes.SecretKey != "" || *got.Attributes.Description != description {
+		t.Errorf("Get = %+v, %v, want the webhook without its secret", got, err)
+	}
+
+	event, _, err := client.Webhooks.Ping(ctx, wh.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Emit(ctx, up.WebhookEventTransactionCreated, "tx-1")
+	if len(received) != 2 || received[0].ID != event.ID || received[1].TransactionID() != "tx-1" {
+		t.Errorf("receiver got %d events", len(received))
+	}
+
+	logs, _, err := client.Webhooks.ListLogs(ctx, wh.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs.Data) != 2 {
+		t.Fatalf("got %d delivery logs, want 2", len(logs.Data))
+	}
+	for _, log := range logs.Data {
+		if log.Attributes.DeliveryStatus != up.WebhookDeliveryStatusDelivered || log.Attributes.Response.StatusCode != http.StatusOK {
+			t.Errorf("log %s: status %s", log.ID, log.Attributes.DeliveryStatus)
+		}
+	}
+	// Newest first
+	if logs.Data[1].Relationships.WebhookEvent.Data.ID != event.ID {
+		t.Errorf("oldest log is for event %s, want the ping %s", logs.Data[1].Relationships.WebhookEvent.Data.ID, event.ID)
+	}
+
+	// A receiver that rejects the signature is logged as a bad response
+	secret = "wrong"
+	srv.Emit(ctx, up.WebhookEventTransactionSettled, "tx-1")
+	if logs := srv.DeliveryLogs(wh.ID); logs[0].Attributes.DeliveryStatus != up.WebhookDeliveryStatusBadResponseCode {
+		t.Errorf("rejected delivery logged as %s", logs[0].Attributes.DeliveryStatus)
+	}
+
+	if _, err := client.Webhooks.Delete(ctx, wh.ID); err != nil {
+		t.Fatal(err)
+	}
+	list, _, err := client.Webhooks.List(ctx, nil)
+	if err != nil || len(list.Data) != 0 {
+		t.Errorf("webhooks after delete = %v, %v", list, err)
+	}
+	if _, _, err := client.Webhooks.ListLogs(ctx, wh.ID, nil); !errors.Is(err, up.ErrNotFound) {
+		t.Errorf("logs of a deleted webhook returned %v, want ErrNotFound", err)
+	}
+	if _, _, err := client.Webhooks.Create(ctx, "not a url", nil); !errors.Is(err, up.ErrValidation) {
+		t.Errorf("creating a webhook with a relative URL returned %v, want ErrValidation", err)
+	}
+}
+
+func TestServerRequiresToken(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	client := srv.UpClient(up.WithTokenSource(up.StaticToken("wrong")))
+	if _, _, err := client.Utility.Ping(context.Background()); !errors.Is(err, up.ErrUnauthorized) {
+		t.Errorf("Ping with a bad token returned %v, want ErrUnauthorized", err)
+	}
+	if _, _, err := srv.UpClient().Utility.Ping(context.Background()); err != nil {
+		t.Errorf("Ping returned %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package uptest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/jordanst3wart/up-client/up"
+)
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	webhooks := make([]up.Webhook, len(s.webhooks))
+	for i, wh := range s.webhooks {
+		webhooks[i] = withoutSecret(wh)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, s.URL, webhooks)
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var body up.WebhookCreateRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	attrs := body.Data.Attributes
+	if u, err := url.Parse(attrs.URL); err != nil || !u.IsAbs() || len(attrs.URL) > 300 {
+		writeError(w, http.StatusBadRequest, "Invalid Request", "url must be an absolute URL of at most 300 characters.")
+		return
+	}
+	if attrs.Description != nil && len(*attrs.Description) > 64 {
+		writeError(w, http.StatusBadRequest, "Invalid Request", "description must be at most 64 characters.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.webhooks) >= maxWebhooks {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request", fmt.Sprintf("An account can have at most %d webhooks.", maxWebhooks))
+		return
+	}
+
+	wh := up.Webhook{
+		Type: "webhooks",
+		ID:   s.newID("webhook"),
+		Attributes: up.WebhookAttributes{
+			URL:         attrs.URL,
+			Description: attrs.Description,
+			SecretKey:   newSecretKey(),
+			CreatedAt:   s.Now(),
+		},
+	}
+	s.webhooks = append(s.webhooks, wh)
+	writeJSON(w, http.StatusCreated, &up.WebhookResponse{Data: wh})
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wh := s.webhook(r.PathValue("id"))
+	if wh == nil {
+		writeNotFound(w, "webhook")
+		return
+	}
+	writeJSON(w, http.StatusOK, &up.WebhookResponse{Data: withoutSecret(*wh)})
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.webhook(id) == nil {
+		writeNotFound(w, "webhook")
+		return
+	}
+	s.webhooks = slices.DeleteFunc(s.webhooks, func(wh up.Webhook) bool { return wh.ID == id })
+	delete(s.logs, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) pingWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	wh := s.webhook(r.PathValue("id"))
+	if wh == nil {
+		s.mu.Unlock()
+		writeNotFound(w, "webhook")
+		return
+	}
+	target := *wh
+	event := s.newEvent(target.ID, up.WebhookEventPing, "")
+	s.mu.Unlock()
+
+	// Deliver without holding the lock, as the receiver may call back into the server
+	s.deliver(r.Context(), target, event)
+	writeJSON(w, http.StatusCreated, &up.WebhookEventResponse{Data: *event})
+}
+
+func (s *Server) listWebhookLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	id := r.PathValue("id")
+	if s.webhook(id) == nil {
+		s.mu.Unlock()
+		writeNotFound(w, "webhook")
+		return
+	}
+	logs := slices.Clone(s.logs[id])
+	s.mu.Unlock()
+
+	writePage(w, r, s.URL, logs)
+}
+
+// Emit delivers an event of the given type for a transaction to every
+// registered webhook, recording a delivery log for each attempt
+func (s *Server) Emit(ctx context.Context, eventType up.WebhookEventTypeEnum, transactionID string) {
+	s.mu.Lock()
+	webhooks := slices.Clone(s.webhooks)
+	events := make([]*up.WebhookEvent, len(webhooks))
+	for i, wh := range webhooks {
+		events[i] = s.newEvent(wh.ID, eventType, transactionID)
+	}
+	s.mu.Unlock()
+
+	for i, wh := range webhooks {
+		s.deliver(ctx, wh, events[i])
+	}
+}
+
+// DeliveryLogs returns the delivery logs of a webhook, newest first
+func (s *Server) DeliveryLogs(webhookID string) []up.WebhookDeliveryLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.logs[webhookID])
+}
+
+func (s *Server) newEvent(webhookID string, eventType up.WebhookEventTypeEnum, transactionID string) *up.WebhookEvent {
+	event := &up.WebhookEvent{
+		Type: "webhook-events",
+		ID:   s.newID("event"),
+		Attributes: up.WebhookEventAttributes{
+			EventType: eventType,
+			CreatedAt: s.Now(),
+		},
+	}
+	event.Relationships.Webhook.Data.Type = "webhooks"
+	event.Relationships.Webhook.Data.ID = webhookID
+	if transactionID != "" {
+		event.Relationships.Transaction = &up.WebhookEventRelationship{}
+		event.Relationships.Transaction.Data.Type = "transactions"
+		event.Relationships.Transaction.Data.ID = transactionID
+	}
+	return event
+}
+
+// deliver posts a signed event to a webhook and records the outcome
+func (s *Server) deliver(ctx context.Context, wh up.Webhook, event *up.WebhookEvent) {
+	body, _ := json.Marshal(&up.WebhookEventResponse{Data: *event})
+
+	var log up.WebhookDeliveryLog
+	log.Type = "webhook-delivery-logs"
+	log.Attributes.Request.Body = string(body)
+	log.Relationships.WebhookEvent.Data.Type = "webhook-events"
+	log.Relationships.WebhookEvent.Data.ID = event.ID
+	log.Attributes.DeliveryStatus = up.WebhookDeliveryStatusUndeliverable
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.Attributes.URL, bytes.NewReader(body))
+	if err == nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(up.SignatureHeader, hex.EncodeToString(up.SignWebhookBody([]byte(wh.Attributes.SecretKey), body)))
+
+		if resp, err := s.DeliveryClient.Do(req); err == nil {
+			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+
+			log.Attributes.Response = &struct {
+				StatusCode int    `json:"statusCode"`
+				Body       string `json:"body"`
+			}{StatusCode: resp.StatusCode, Body: string(respBody)}
+
+			log.Attributes.DeliveryStatus = up.WebhookDeliveryStatusBadResponseCode
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				log.Attributes.DeliveryStatus = up.WebhookDeliveryStatusDelivered
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	log.ID = s.newID("log")
+	log.Attributes.CreatedAt = s.Now()
+	if s.webhook(wh.ID) != nil {
+		s.logs[wh.ID] = slices.Insert(s.logs[wh.ID], 0, log)
+	}
+}
+
+func (s *Server) webhook(id string) *up.Webhook {
+	for i := range s.webhooks {
+		if s.webhooks[i].ID == id {
+			return &s.webhooks[i]
+		}
+	}
+	return nil
+}
+
+// withoutSecret hides the secret key, which Up only returns when a webhook is created
+func withoutSecret(wh up.Webhook) up.Webhook {
+	wh.Attributes.SecretKey = ""
+	return wh
+}
+
+func newSecretKey() string {
+	var b [32]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}